@@ -1,35 +1,66 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/homedepot/arcade/pkg/aws"
+	"github.com/homedepot/arcade/pkg/azure"
+	"github.com/homedepot/arcade/pkg/config"
+	"github.com/homedepot/arcade/pkg/exec"
 	"github.com/homedepot/arcade/pkg/google"
-	"github.com/homedepot/arcade/pkg/http"
+	arcadehttp "github.com/homedepot/arcade/pkg/http"
+	"github.com/homedepot/arcade/pkg/kubernetes"
 	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/oidc"
+	"github.com/homedepot/arcade/pkg/provider"
 	"github.com/homedepot/arcade/pkg/rancher"
+	"github.com/homedepot/arcade/pkg/vault"
+)
+
+const (
+	kubernetesCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	// shutdownTimeout is how long requests in flight and providers
+	// cleaning up have to finish when arcade shuts down.
+	shutdownTimeout = 30 * time.Second
+	// startupTimeout is how long checking the configuration of a provider
+	// against its upstream may take.
+	startupTimeout = 30 * time.Second
 )
 
 var (
-	r = gin.Default()
+	r        = gin.Default()
+	registry *provider.Registry
 )
 
 func init() {
 	gin.ForceConsoleColor()
 
-	apiKey := mustGetenv("ARCADE_API_KEY")
-	r.Use(middleware.NewApiKeyAuth(apiKey))
+	cfg := mustLoadConfig()
 
-	googleClient := google.NewClient()
-	r.Use(middleware.SetGoogleClient(googleClient))
+	r.Use(middleware.NewApiKeysAuth(mustGetAPIKeys(cfg)))
 
-	if s := os.Getenv("RANCHER_ENABLED"); s == "TRUE" {
-		rancherClient := mustInstantiateRancherClient()
-		r.Use(middleware.SetRancherClient(rancherClient))
-	}
+	registry = mustInstantiateProviderRegistry(cfg)
+	r.Use(middleware.SetProviderRegistry(registry))
 
-	r.GET("/tokens", http.GetToken)
+	r.GET("/tokens", arcadehttp.GetToken)
+	r.GET("/kubeconfig", arcadehttp.GetKubeconfig)
+	r.GET("/providers/:provider/clusters", arcadehttp.GetClusters)
+	r.GET("/providers/:provider/tokens", middleware.RequireAPIKeyName(middleware.AdminAPIKeyName), arcadehttp.GetProviderTokens)
+	r.DELETE("/providers/:provider/tokens", middleware.RequireAPIKeyName(middleware.AdminAPIKeyName), arcadehttp.DeleteProviderTokens)
 }
 
 func mustGetenv(env string) (s string) {
@@ -40,23 +71,556 @@ func mustGetenv(env string) (s string) {
 	return
 }
 
-func mustInstantiateRancherClient() rancher.Client {
-	rancherURL := mustGetenv("RANCHER_URL")
-	rancherUsername := mustGetenv("RANCHER_USERNAME")
-	rancherPassword := mustGetenv("RANCHER_PASSWORD")
+// mustLoadConfig merges the providers configured with environment
+// variables with the config file set by ARCADE_CONFIG.
+func mustLoadConfig() config.Config {
+	cfg, err := config.FromEnv()
+	if err != nil {
+		log.Fatal(err.Error() + "; exiting.")
+	}
+
+	if path := os.Getenv("ARCADE_CONFIG"); path != "" {
+		fileCfg, err := config.Load(path)
+		if err != nil {
+			log.Fatal("error loading ARCADE_CONFIG: " + err.Error())
+		}
+
+		cfg.APIKeys = fileCfg.APIKeys
+		cfg.Providers = mergeProviders(cfg.Providers, fileCfg.Providers)
+	}
+
+	return cfg
+}
+
+// mustGetAPIKeys returns the API keys by name. ARCADE_API_KEY is named
+// default and ARCADE_ADMIN_API_KEY, if it is set, admin. Every access
+// boundary must name one of the keys.
+func mustGetAPIKeys(cfg config.Config) map[string]string {
+	apiKeys := map[string]string{
+		middleware.DefaultAPIKeyName: mustGetenv("ARCADE_API_KEY"),
+	}
+
+	if adminAPIKey := os.Getenv("ARCADE_ADMIN_API_KEY"); adminAPIKey != "" {
+		apiKeys[middleware.AdminAPIKeyName] = adminAPIKey
+	}
+
+	for _, apiKey := range cfg.APIKeys {
+		if apiKey.Name == "" || apiKey.Key == "" {
+			log.Fatal("API key name or key not set; exiting.")
+		}
+
+		if _, exists := apiKeys[apiKey.Name]; exists {
+			log.Fatal("duplicate API key name: " + apiKey.Name + "; exiting.")
+		}
+
+		apiKeys[apiKey.Name] = apiKey.Key
+	}
+
+	// A key shared by two names would authenticate its callers as either
+	// name, for example as default instead of a name with an access
+	// boundary.
+	names := map[string]string{}
+
+	for name, apiKey := range apiKeys {
+		if other, exists := names[apiKey]; exists {
+			log.Fatal(fmt.Sprintf("API keys %s and %s have the same value; exiting.", other, name))
+		}
+
+		names[apiKey] = name
+	}
+
+	for _, p := range cfg.Providers {
+		for _, ab := range p.Google.AccessBoundaries {
+			if _, exists := apiKeys[ab.APIKey]; !exists {
+				log.Fatal(fmt.Sprintf("access boundary of provider %s names unknown API key %s; exiting.", p.Name, ab.APIKey))
+			}
+		}
+	}
+
+	return apiKeys
+}
+
+// mustInstantiateProviderRegistry registers the configured providers.
+func mustInstantiateProviderRegistry(cfg config.Config) *provider.Registry {
+	registry := provider.NewRegistry()
+
+	for _, p := range cfg.Providers {
+		tp, err := newTokenProvider(p)
+		if err != nil {
+			log.Fatal(fmt.Sprintf("error instantiating provider %s: %s", p.Name, err.Error()))
+		}
+
+		err = registry.Register(p.Name, p.Type, tp)
+		if err != nil {
+			log.Fatal(err.Error() + "; exiting.")
+		}
+
+		clusters, err := newClusters(p)
+		if err != nil {
+			log.Fatal(fmt.Sprintf("error instantiating clusters of provider %s: %s", p.Name, err.Error()))
+		}
+
+		err = registry.RegisterClusters(p.Name, clusters...)
+		if err != nil {
+			log.Fatal(err.Error() + "; exiting.")
+		}
+	}
+
+	// API keys with an access boundary may only use the providers that
+	// downscope their tokens, so they never get a full token elsewhere.
+	for _, p := range cfg.Providers {
+		for _, ab := range p.Google.AccessBoundaries {
+			registry.RestrictAPIKey(ab.APIKey, p.Name)
+		}
+	}
+
+	return registry
+}
+
+// mergeProviders appends the providers declared in the config file to
+// the providers configured with environment variables. The default
+// google provider is dropped if the config file declares one named google.
+func mergeProviders(envProviders, fileProviders []config.Provider) []config.Provider {
+	for _, p := range fileProviders {
+		if p.Name != provider.DefaultName {
+			continue
+		}
+
+		providers := []config.Provider{}
+
+		for _, e := range envProviders {
+			if e.Name != provider.DefaultName {
+				providers = append(providers, e)
+			}
+		}
+
+		envProviders = providers
+	}
+
+	return append(envProviders, fileProviders...)
+}
+
+func newTokenProvider(p config.Provider) (provider.TokenProvider, error) {
+	switch p.Type {
+	case provider.TypeGoogle:
+		switch p.Google.CredentialsType {
+		case "", google.CredentialsTypeServiceAccount, google.CredentialsTypeExternalAccount:
+		default:
+			return nil, fmt.Errorf("unsupported credentials type: %s", p.Google.CredentialsType)
+		}
+
+		expirySkew := p.Google.ExpirySkew
+		if expirySkew == 0 {
+			expirySkew = google.DefaultExpirySkew
+		}
+
+		googleClient := newGoogleClient(p.Google)
+		googleClient.WithExpirySkew(expirySkew)
+
+		return google.NewTokenProvider(googleClient, google.TokenProviderOptions{
+			ExpirySkew:                expirySkew,
+			AllowedScopes:             p.Google.AllowedScopes,
+			AllowedServiceAccounts:    p.Google.AllowedServiceAccounts,
+			ImpersonateServiceAccount: p.Google.ImpersonateServiceAccount,
+			AccessBoundaries:          newAccessBoundaries(p.Google.AccessBoundaries),
+		}), nil
+	case provider.TypeRancher:
+		if p.Rancher.APIKey == "" && p.Rancher.APIKeyFile == "" && (p.Rancher.Username == "" || p.Rancher.Password == "") {
+			return nil, errors.New("username and password or API key not set")
+		}
+
+		// Tokens derived from an API key must be short-lived, so Rancher's
+		// default TTL, which may never expire, is not used for them.
+		if (p.Rancher.APIKey != "" || p.Rancher.APIKeyFile != "") && p.Rancher.TTL <= 0 {
+			return nil, errors.New("ttl not set, it is required with an API key")
+		}
+
+		rancherClient, err := newRancherClient(p.Rancher)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.Rancher.Description != "" {
+			hostname, _ := os.Hostname()
+
+			description, err := rancher.NewDescription(p.Rancher.Description, rancher.DescriptionData{
+				Hostname: hostname,
+				Provider: p.Name,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			rancherClient.WithDescription(description)
+		}
+
+		// Check the URL and auth provider now rather than on the first request.
+		ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+		defer cancel()
+
+		err = rancherClient.CheckAuthProvider(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		maxCacheAge := p.Rancher.MaxCacheAge
+		if maxCacheAge == 0 {
+			maxCacheAge = rancher.DefaultMaxCacheAge
+		}
+
+		validationInterval := p.Rancher.ValidationInterval
+		if validationInterval == 0 {
+			validationInterval = rancher.DefaultValidationInterval
+		}
+
+		return rancher.NewTokenProvider(rancherClient, rancher.TokenProviderOptions{
+			RevokeOnShutdown:   p.Rancher.RevokeOnShutdown,
+			MaxCacheAge:        maxCacheAge,
+			ValidationInterval: validationInterval,
+		}), nil
+	case provider.TypeAWS:
+		return aws.NewTokenProvider(newAWSClient(p.AWS)), nil
+	case provider.TypeAzure:
+		return azure.NewTokenProvider(newAzureClient(p.Azure)), nil
+	case provider.TypeKubernetes:
+		kubernetesClient, err := newKubernetesClient(p.Kubernetes)
+		if err != nil {
+			return nil, err
+		}
+
+		return kubernetes.NewTokenProvider(kubernetesClient), nil
+	case provider.TypeVault:
+		vaultClient, err := newVaultClient(p.Vault)
+		if err != nil {
+			return nil, err
+		}
+
+		return vault.NewTokenProvider(vaultClient), nil
+	case provider.TypeOIDC:
+		if p.OIDC.RefreshToken == "" && p.OIDC.RefreshTokenFile == "" {
+			return nil, errors.New("refresh token or refresh token file not set")
+		}
+
+		return oidc.NewTokenProvider(newOIDCClient(p.OIDC)), nil
+	case provider.TypeExec:
+		return exec.NewTokenProvider(newExecClient(p.Exec)), nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", p.Type)
+	}
+}
+
+// newClusters returns the clusters configured for p. A kubernetes
+// provider without configured clusters serves kubeconfigs for the cluster
+// it creates tokens with.
+func newClusters(p config.Provider) ([]provider.Cluster, error) {
+	clusters := []provider.Cluster{}
+
+	for _, c := range p.Clusters {
+		cluster := provider.Cluster{
+			Name:                  c.Name,
+			Server:                c.Server,
+			InsecureSkipTLSVerify: c.InsecureSkipTLSVerify,
+		}
+
+		switch {
+		case c.CAData != "":
+			b, err := base64.StdEncoding.DecodeString(c.CAData)
+			if err != nil {
+				return nil, fmt.Errorf("caData of cluster %s is not valid: %s", c.Name, err.Error())
+			}
+
+			cluster.CertificateAuthorityData = b
+		case c.CAFile != "":
+			b, err := ioutil.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, err
+			}
+
+			cluster.CertificateAuthorityData = b
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	if p.Type == provider.TypeKubernetes && len(clusters) == 0 {
+		cluster := provider.Cluster{
+			Name:   p.Name,
+			Server: p.Kubernetes.URL,
+		}
+
+		if cluster.Server == "" {
+			cluster.Server = kubernetes.DefaultURL
+		}
+
+		caFile := p.Kubernetes.CAFile
+		if caFile == "" {
+			caFile = kubernetesCAFile
+		}
+
+		if b, err := ioutil.ReadFile(caFile); err == nil {
+			cluster.CertificateAuthorityData = b
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, nil
+}
+
+func newGoogleClient(cfg config.Google) google.Client {
+	googleClient := google.NewClient()
+	googleClient.WithCredentialsFile(cfg.CredentialsFile)
+	googleClient.WithCredentialsType(cfg.CredentialsType)
+	googleClient.WithImpersonateServiceAccount(cfg.ImpersonateServiceAccount)
+	googleClient.WithDelegates(cfg.Delegates)
+	googleClient.WithScopes(cfg.Scopes)
+
+	return googleClient
+}
+
+func newAccessBoundaries(cfg []config.AccessBoundary) map[string]google.AccessBoundary {
+	accessBoundaries := map[string]google.AccessBoundary{}
+
+	for _, ab := range cfg {
+		accessBoundary := google.AccessBoundary{}
+
+		for _, rule := range ab.Rules {
+			r := google.AccessBoundaryRule{
+				AvailableResource:    rule.AvailableResource,
+				AvailablePermissions: rule.AvailablePermissions,
+			}
+
+			if rule.AvailabilityCondition != nil {
+				r.AvailabilityCondition = &google.AvailabilityCondition{
+					Expression:  rule.AvailabilityCondition.Expression,
+					Title:       rule.AvailabilityCondition.Title,
+					Description: rule.AvailabilityCondition.Description,
+				}
+			}
+
+			accessBoundary.AccessBoundaryRules = append(accessBoundary.AccessBoundaryRules, r)
+		}
+
+		accessBoundaries[ab.APIKey] = accessBoundary
+	}
+
+	return accessBoundaries
+}
+
+func newRancherClient(cfg config.Rancher) (rancher.Client, error) {
+	transport, err := newRancherTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	rancherClient := rancher.NewClient()
-	rancherClient.WithURL(rancherURL)
-	rancherClient.WithUsername(rancherUsername)
-	rancherClient.WithPassword(rancherPassword)
+	rancherClient.WithTransport(transport)
+	rancherClient.WithCAFile(cfg.CAFile)
+	rancherClient.WithTimeout(cfg.Timeout)
+	rancherClient.WithURL(cfg.URL)
+	rancherClient.WithAuthProvider(cfg.AuthProvider)
+	rancherClient.WithUsername(cfg.Username)
+	rancherClient.WithPassword(cfg.Password)
+	rancherClient.WithAPIKey(cfg.APIKey)
+	rancherClient.WithAPIKeyFile(cfg.APIKeyFile)
+	rancherClient.WithTTL(cfg.TTL)
+
+	if cfg.TokensFile != "" {
+		rancherClient.WithTokensFile(cfg.TokensFile)
+	}
+
+	return rancherClient, nil
+}
+
+// newRancherTransport returns the transport of requests to Rancher, with
+// the configured proxy and client certificate.
+func newRancherTransport(cfg config.Rancher) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipTLSVerify,
+	}
 
-	return rancherClient
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proxy URL: %w", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return transport, nil
+}
+
+func newAWSClient(cfg config.AWS) aws.Client {
+	awsClient := aws.NewClient()
+	awsClient.WithClusterName(cfg.ClusterName)
+	awsClient.WithRegion(cfg.Region)
+	awsClient.WithEndpoint(cfg.STSEndpoint)
+
+	return awsClient
+}
+
+func newAzureClient(cfg config.Azure) azure.Client {
+	azureClient := azure.NewClient()
+	azureClient.WithTenantID(cfg.TenantID)
+	azureClient.WithClientID(cfg.ClientID)
+	azureClient.WithClientSecret(cfg.ClientSecret)
+	azureClient.WithClientCertificate(cfg.ClientCertificatePath)
+	azureClient.WithFederatedTokenFile(cfg.FederatedTokenFile)
+
+	if cfg.AuthorityHost != "" {
+		azureClient.WithAuthorityHost(cfg.AuthorityHost)
+	}
+
+	if cfg.ServerApplicationID != "" {
+		azureClient.WithServerApplicationID(cfg.ServerApplicationID)
+	}
+
+	return azureClient
+}
+
+func newKubernetesClient(cfg config.Kubernetes) (kubernetes.Client, error) {
+	kubernetesClient := kubernetes.NewClient()
+	kubernetesClient.WithNamespace(cfg.Namespace)
+	kubernetesClient.WithServiceAccount(cfg.ServiceAccount)
+	kubernetesClient.WithAudience(cfg.Audience)
+
+	if cfg.URL != "" {
+		kubernetesClient.WithURL(cfg.URL)
+	}
+
+	if cfg.BearerTokenFile != "" {
+		kubernetesClient.WithBearerTokenFile(cfg.BearerTokenFile)
+	}
+
+	if cfg.ExpirationSeconds != 0 {
+		kubernetesClient.WithExpirationSeconds(cfg.ExpirationSeconds)
+	}
+
+	caFile := cfg.CAFile
+	if caFile == "" {
+		caFile = kubernetesCAFile
+	}
+
+	b, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		// Only fail when the CA was configured explicitly, otherwise
+		// fall back to the system roots.
+		if cfg.CAFile != "" {
+			return nil, err
+		}
+
+		return kubernetesClient, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, errors.New("no certificates found in " + caFile)
+	}
+
+	kubernetesClient.WithTransport(&http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{RootCAs: pool},
+	})
+
+	return kubernetesClient, nil
+}
+
+func newVaultClient(cfg config.Vault) (vault.Client, error) {
+	vaultClient := vault.NewClient()
+	vaultClient.WithURL(cfg.Addr)
+	vaultClient.WithRole(cfg.Role)
+	vaultClient.WithKubernetesNamespace(cfg.KubernetesNamespace)
+	vaultClient.WithTTL(cfg.TTL)
+	vaultClient.WithAuthMountPath(cfg.AuthMountPath)
+
+	if cfg.MountPath != "" {
+		vaultClient.WithMountPath(cfg.MountPath)
+	}
+
+	switch cfg.AuthMethod {
+	case vault.AuthMethodAppRole:
+		vaultClient.WithAuthMethod(cfg.AuthMethod)
+		vaultClient.WithRoleID(cfg.RoleID)
+		vaultClient.WithSecretID(cfg.SecretID)
+	case vault.AuthMethodKubernetes, "":
+		vaultClient.WithAuthMethod(vault.AuthMethodKubernetes)
+		vaultClient.WithAuthRole(cfg.AuthRole)
+
+		if cfg.JWTFile != "" {
+			vaultClient.WithJWTFile(cfg.JWTFile)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported auth method: %s", cfg.AuthMethod)
+	}
+
+	return vaultClient, nil
+}
+
+func newOIDCClient(cfg config.OIDC) oidc.Client {
+	oidcClient := oidc.NewClient()
+	oidcClient.WithIssuerURL(cfg.IssuerURL)
+	oidcClient.WithClientID(cfg.ClientID)
+	oidcClient.WithClientSecret(cfg.ClientSecret)
+	oidcClient.WithRefreshToken(cfg.RefreshToken)
+	oidcClient.WithRefreshTokenFile(cfg.RefreshTokenFile)
+
+	return oidcClient
+}
+
+func newExecClient(cfg config.Exec) exec.Client {
+	execClient := exec.NewClient()
+	execClient.WithCommand(cfg.Command)
+	execClient.WithArgs(cfg.Args)
+	execClient.WithEnv(cfg.Env)
+
+	if cfg.APIVersion != "" {
+		execClient.WithAPIVersion(cfg.APIVersion)
+	}
+
+	return execClient
 }
 
 // Run arcade on port 1982.
 func main() {
-	err := r.Run(":1982")
-	if err != nil {
-		panic(err)
+	srv := &http.Server{
+		Addr:    ":1982",
+		Handler: r,
+	}
+
+	go func() {
+		err := srv.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			panic(err)
+		}
+	}()
+
+	// Wait for a signal to shut down gracefully, so providers can clean up
+	// the tokens they created.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Println("error shutting down server: " + err.Error())
+	}
+
+	if err := registry.Close(ctx); err != nil {
+		log.Println(err.Error())
 	}
 }
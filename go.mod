@@ -3,8 +3,10 @@ module github.com/homedepot/arcade
 go 1.14
 
 require (
-  github.com/gin-gonic/gin v1.6.3
-  github.com/onsi/ginkgo v1.15.0
-  github.com/onsi/gomega v1.10.5
-  golang.org/x/oauth2 v0.0.0-20200902213428-5d25da1a8d43
+	github.com/aws/aws-sdk-go v1.38.0
+	github.com/gin-gonic/gin v1.6.3
+	github.com/onsi/ginkgo v1.15.0
+	github.com/onsi/gomega v1.10.5
+	golang.org/x/oauth2 v0.0.0-20211104180415-d3ed0bb246c8
+	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,234 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package awsfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/homedepot/arcade/pkg/aws"
+)
+
+type FakeClient struct {
+	NewTokenStub        func(context.Context) (aws.Token, error)
+	newTokenMutex       sync.RWMutex
+	newTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	newTokenReturns struct {
+		result1 aws.Token
+		result2 error
+	}
+	newTokenReturnsOnCall map[int]struct {
+		result1 aws.Token
+		result2 error
+	}
+	WithClusterNameStub        func(string)
+	withClusterNameMutex       sync.RWMutex
+	withClusterNameArgsForCall []struct {
+		arg1 string
+	}
+	WithEndpointStub        func(string)
+	withEndpointMutex       sync.RWMutex
+	withEndpointArgsForCall []struct {
+		arg1 string
+	}
+	WithRegionStub        func(string)
+	withRegionMutex       sync.RWMutex
+	withRegionArgsForCall []struct {
+		arg1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) NewToken(arg1 context.Context) (aws.Token, error) {
+	fake.newTokenMutex.Lock()
+	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
+	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
+	fake.recordInvocation("NewToken", []interface{}{arg1})
+	fake.newTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewTokenCallCount() int {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	return len(fake.newTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewTokenCalls(stub func(context.Context) (aws.Token, error)) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = stub
+}
+
+func (fake *FakeClient) NewTokenArgsForCall(i int) context.Context {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	argsForCall := fake.newTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) NewTokenReturns(result1 aws.Token, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	fake.newTokenReturns = struct {
+		result1 aws.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 aws.Token, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	if fake.newTokenReturnsOnCall == nil {
+		fake.newTokenReturnsOnCall = make(map[int]struct {
+			result1 aws.Token
+			result2 error
+		})
+	}
+	fake.newTokenReturnsOnCall[i] = struct {
+		result1 aws.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WithClusterName(arg1 string) {
+	fake.withClusterNameMutex.Lock()
+	fake.withClusterNameArgsForCall = append(fake.withClusterNameArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithClusterNameStub
+	fake.recordInvocation("WithClusterName", []interface{}{arg1})
+	fake.withClusterNameMutex.Unlock()
+	if stub != nil {
+		fake.WithClusterNameStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithClusterNameCallCount() int {
+	fake.withClusterNameMutex.RLock()
+	defer fake.withClusterNameMutex.RUnlock()
+	return len(fake.withClusterNameArgsForCall)
+}
+
+func (fake *FakeClient) WithClusterNameCalls(stub func(string)) {
+	fake.withClusterNameMutex.Lock()
+	defer fake.withClusterNameMutex.Unlock()
+	fake.WithClusterNameStub = stub
+}
+
+func (fake *FakeClient) WithClusterNameArgsForCall(i int) string {
+	fake.withClusterNameMutex.RLock()
+	defer fake.withClusterNameMutex.RUnlock()
+	argsForCall := fake.withClusterNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithEndpoint(arg1 string) {
+	fake.withEndpointMutex.Lock()
+	fake.withEndpointArgsForCall = append(fake.withEndpointArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithEndpointStub
+	fake.recordInvocation("WithEndpoint", []interface{}{arg1})
+	fake.withEndpointMutex.Unlock()
+	if stub != nil {
+		fake.WithEndpointStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithEndpointCallCount() int {
+	fake.withEndpointMutex.RLock()
+	defer fake.withEndpointMutex.RUnlock()
+	return len(fake.withEndpointArgsForCall)
+}
+
+func (fake *FakeClient) WithEndpointCalls(stub func(string)) {
+	fake.withEndpointMutex.Lock()
+	defer fake.withEndpointMutex.Unlock()
+	fake.WithEndpointStub = stub
+}
+
+func (fake *FakeClient) WithEndpointArgsForCall(i int) string {
+	fake.withEndpointMutex.RLock()
+	defer fake.withEndpointMutex.RUnlock()
+	argsForCall := fake.withEndpointArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithRegion(arg1 string) {
+	fake.withRegionMutex.Lock()
+	fake.withRegionArgsForCall = append(fake.withRegionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithRegionStub
+	fake.recordInvocation("WithRegion", []interface{}{arg1})
+	fake.withRegionMutex.Unlock()
+	if stub != nil {
+		fake.WithRegionStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithRegionCallCount() int {
+	fake.withRegionMutex.RLock()
+	defer fake.withRegionMutex.RUnlock()
+	return len(fake.withRegionArgsForCall)
+}
+
+func (fake *FakeClient) WithRegionCalls(stub func(string)) {
+	fake.withRegionMutex.Lock()
+	defer fake.withRegionMutex.Unlock()
+	fake.WithRegionStub = stub
+}
+
+func (fake *FakeClient) WithRegionArgsForCall(i int) string {
+	fake.withRegionMutex.RLock()
+	defer fake.withRegionMutex.RUnlock()
+	argsForCall := fake.withRegionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	fake.withClusterNameMutex.RLock()
+	defer fake.withClusterNameMutex.RUnlock()
+	fake.withEndpointMutex.RLock()
+	defer fake.withEndpointMutex.RUnlock()
+	fake.withRegionMutex.RLock()
+	defer fake.withRegionMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ aws.Client = new(FakeClient)
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+const (
+	// clusterIDHeader binds the presigned request to a single EKS cluster.
+	clusterIDHeader = "x-k8s-aws-id"
+	// tokenPrefix is the prefix EKS expects on bearer tokens.
+	tokenPrefix = "k8s-aws-v1."
+	// presignExpiration is the maximum lifetime EKS accepts for a presigned URL.
+	presignExpiration = 15 * time.Minute
+	defaultRegion     = "us-east-1"
+)
+
+var (
+	errClusterNameNotSet = errors.New("error getting token: cluster name not set")
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	NewToken(context.Context) (Token, error)
+	WithClusterName(string)
+	WithRegion(string)
+	WithEndpoint(string)
+}
+
+func NewClient() Client {
+	return &client{}
+}
+
+type client struct {
+	clusterName string
+	region      string
+	endpoint    string
+}
+
+func (c *client) WithClusterName(clusterName string) {
+	c.clusterName = clusterName
+}
+
+func (c *client) WithRegion(region string) {
+	c.region = region
+}
+
+func (c *client) WithEndpoint(endpoint string) {
+	c.endpoint = endpoint
+}
+
+// NewToken presigns an STS GetCallerIdentity request for the configured
+// cluster using the default credential chain (environment, shared config
+// and web identity) and encodes it as an EKS bearer token.
+func (c *client) NewToken(ctx context.Context) (Token, error) {
+	t := Token{}
+
+	if c.clusterName == "" {
+		return t, errClusterNameNotSet
+	}
+
+	config := aws.NewConfig().WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint)
+
+	if c.region != "" {
+		config = config.WithRegion(c.region)
+	}
+
+	if c.endpoint != "" {
+		config = config.WithEndpoint(c.endpoint)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return t, err
+	}
+
+	if aws.StringValue(sess.Config.Region) == "" {
+		sess.Config.Region = aws.String(defaultRegion)
+	}
+
+	req, _ := sts.New(sess).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	req.SetContext(ctx)
+	req.HTTPRequest.Header.Add(clusterIDHeader, c.clusterName)
+
+	// The URL is signed now, so the token is valid for presignExpiration
+	// from this point on.
+	now := time.Now().In(time.UTC)
+
+	u, err := req.Presign(presignExpiration)
+	if err != nil {
+		return t, err
+	}
+
+	t.Token = tokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(u))
+	t.ExpiresAt = now.Add(presignExpiration)
+
+	return t, nil
+}
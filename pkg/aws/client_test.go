@@ -0,0 +1,129 @@
+package aws_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/aws"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const payloadGetCallerIdentity = `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:iam::123456789012:role/arcade</Arn>
+    <UserId>AROAEXAMPLE:arcade</UserId>
+    <Account>123456789012</Account>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`
+
+var _ = Describe("Client", func() {
+	var (
+		server *ghttp.Server
+		client Client
+		t      Token
+		err    error
+	)
+
+	BeforeEach(func() {
+		os.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "fake-secret")
+		os.Setenv("AWS_CONFIG_FILE", "/dev/null")
+		os.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+		server = ghttp.NewServer()
+		client = NewClient()
+		client.WithClusterName("test-cluster")
+		client.WithRegion("us-east-1")
+		client.WithEndpoint(server.URL())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.Unsetenv("AWS_ACCESS_KEY_ID")
+		os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+		os.Unsetenv("AWS_CONFIG_FILE")
+		os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE")
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			t, err = client.NewToken(context.Background())
+		})
+
+		When("the cluster name is not set", func() {
+			BeforeEach(func() {
+				client.WithClusterName("")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: cluster name not set"))
+			})
+		})
+
+		When("no credentials are available", func() {
+			BeforeEach(func() {
+				os.Unsetenv("AWS_ACCESS_KEY_ID")
+				os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+				os.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("AWS_EC2_METADATA_DISABLED")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("it succeeds", func() {
+			var presigned *url.URL
+
+			JustBeforeEach(func() {
+				Expect(t.Token).To(HavePrefix("k8s-aws-v1."))
+				b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(t.Token, "k8s-aws-v1."))
+				Expect(err).To(BeNil())
+				presigned, err = url.Parse(string(b))
+				Expect(err).To(BeNil())
+			})
+
+			It("presigns a GetCallerIdentity request against the configured endpoint", func() {
+				Expect(err).To(BeNil())
+				Expect(presigned.Scheme + "://" + presigned.Host).To(Equal(server.URL()))
+				Expect(presigned.Query().Get("Action")).To(Equal("GetCallerIdentity"))
+				Expect(presigned.Query().Get("X-Amz-Expires")).To(Equal("900"))
+				Expect(presigned.Query().Get("X-Amz-SignedHeaders")).To(ContainSubstring("x-k8s-aws-id"))
+				Expect(presigned.Query().Get("X-Amz-Credential")).To(HavePrefix("AKIDEXAMPLE/"))
+			})
+
+			It("sets the expiration before the presigned URL expires", func() {
+				Expect(t.ExpiresAt).To(BeTemporally("~", time.Now().Add(15*time.Minute), 5*time.Second))
+			})
+
+			It("is accepted by STS when sent with the cluster header", func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/", presigned.RawQuery),
+					ghttp.VerifyHeaderKV("x-k8s-aws-id", "test-cluster"),
+					ghttp.RespondWith(http.StatusOK, payloadGetCallerIdentity),
+				))
+
+				req, _ := http.NewRequest(http.MethodGet, presigned.String(), nil)
+				req.Header.Add("x-k8s-aws-id", "test-cluster")
+				res, err := http.DefaultClient.Do(req)
+				Expect(err).To(BeNil())
+				defer res.Body.Close()
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+})
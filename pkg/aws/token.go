@@ -0,0 +1,8 @@
+package aws
+
+import "time"
+
+type Token struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,390 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package azurefakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/homedepot/arcade/pkg/azure"
+)
+
+type FakeClient struct {
+	NewTokenStub        func(context.Context) (azure.Token, error)
+	newTokenMutex       sync.RWMutex
+	newTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	newTokenReturns struct {
+		result1 azure.Token
+		result2 error
+	}
+	newTokenReturnsOnCall map[int]struct {
+		result1 azure.Token
+		result2 error
+	}
+	WithAuthorityHostStub        func(string)
+	withAuthorityHostMutex       sync.RWMutex
+	withAuthorityHostArgsForCall []struct {
+		arg1 string
+	}
+	WithClientCertificateStub        func(string)
+	withClientCertificateMutex       sync.RWMutex
+	withClientCertificateArgsForCall []struct {
+		arg1 string
+	}
+	WithClientIDStub        func(string)
+	withClientIDMutex       sync.RWMutex
+	withClientIDArgsForCall []struct {
+		arg1 string
+	}
+	WithClientSecretStub        func(string)
+	withClientSecretMutex       sync.RWMutex
+	withClientSecretArgsForCall []struct {
+		arg1 string
+	}
+	WithFederatedTokenFileStub        func(string)
+	withFederatedTokenFileMutex       sync.RWMutex
+	withFederatedTokenFileArgsForCall []struct {
+		arg1 string
+	}
+	WithServerApplicationIDStub        func(string)
+	withServerApplicationIDMutex       sync.RWMutex
+	withServerApplicationIDArgsForCall []struct {
+		arg1 string
+	}
+	WithTenantIDStub        func(string)
+	withTenantIDMutex       sync.RWMutex
+	withTenantIDArgsForCall []struct {
+		arg1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) NewToken(arg1 context.Context) (azure.Token, error) {
+	fake.newTokenMutex.Lock()
+	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
+	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
+	fake.recordInvocation("NewToken", []interface{}{arg1})
+	fake.newTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewTokenCallCount() int {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	return len(fake.newTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewTokenCalls(stub func(context.Context) (azure.Token, error)) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = stub
+}
+
+func (fake *FakeClient) NewTokenArgsForCall(i int) context.Context {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	argsForCall := fake.newTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) NewTokenReturns(result1 azure.Token, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	fake.newTokenReturns = struct {
+		result1 azure.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 azure.Token, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	if fake.newTokenReturnsOnCall == nil {
+		fake.newTokenReturnsOnCall = make(map[int]struct {
+			result1 azure.Token
+			result2 error
+		})
+	}
+	fake.newTokenReturnsOnCall[i] = struct {
+		result1 azure.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WithAuthorityHost(arg1 string) {
+	fake.withAuthorityHostMutex.Lock()
+	fake.withAuthorityHostArgsForCall = append(fake.withAuthorityHostArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAuthorityHostStub
+	fake.recordInvocation("WithAuthorityHost", []interface{}{arg1})
+	fake.withAuthorityHostMutex.Unlock()
+	if stub != nil {
+		fake.WithAuthorityHostStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAuthorityHostCallCount() int {
+	fake.withAuthorityHostMutex.RLock()
+	defer fake.withAuthorityHostMutex.RUnlock()
+	return len(fake.withAuthorityHostArgsForCall)
+}
+
+func (fake *FakeClient) WithAuthorityHostCalls(stub func(string)) {
+	fake.withAuthorityHostMutex.Lock()
+	defer fake.withAuthorityHostMutex.Unlock()
+	fake.WithAuthorityHostStub = stub
+}
+
+func (fake *FakeClient) WithAuthorityHostArgsForCall(i int) string {
+	fake.withAuthorityHostMutex.RLock()
+	defer fake.withAuthorityHostMutex.RUnlock()
+	argsForCall := fake.withAuthorityHostArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithClientCertificate(arg1 string) {
+	fake.withClientCertificateMutex.Lock()
+	fake.withClientCertificateArgsForCall = append(fake.withClientCertificateArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithClientCertificateStub
+	fake.recordInvocation("WithClientCertificate", []interface{}{arg1})
+	fake.withClientCertificateMutex.Unlock()
+	if stub != nil {
+		fake.WithClientCertificateStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithClientCertificateCallCount() int {
+	fake.withClientCertificateMutex.RLock()
+	defer fake.withClientCertificateMutex.RUnlock()
+	return len(fake.withClientCertificateArgsForCall)
+}
+
+func (fake *FakeClient) WithClientCertificateCalls(stub func(string)) {
+	fake.withClientCertificateMutex.Lock()
+	defer fake.withClientCertificateMutex.Unlock()
+	fake.WithClientCertificateStub = stub
+}
+
+func (fake *FakeClient) WithClientCertificateArgsForCall(i int) string {
+	fake.withClientCertificateMutex.RLock()
+	defer fake.withClientCertificateMutex.RUnlock()
+	argsForCall := fake.withClientCertificateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithClientID(arg1 string) {
+	fake.withClientIDMutex.Lock()
+	fake.withClientIDArgsForCall = append(fake.withClientIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithClientIDStub
+	fake.recordInvocation("WithClientID", []interface{}{arg1})
+	fake.withClientIDMutex.Unlock()
+	if stub != nil {
+		fake.WithClientIDStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithClientIDCallCount() int {
+	fake.withClientIDMutex.RLock()
+	defer fake.withClientIDMutex.RUnlock()
+	return len(fake.withClientIDArgsForCall)
+}
+
+func (fake *FakeClient) WithClientIDCalls(stub func(string)) {
+	fake.withClientIDMutex.Lock()
+	defer fake.withClientIDMutex.Unlock()
+	fake.WithClientIDStub = stub
+}
+
+func (fake *FakeClient) WithClientIDArgsForCall(i int) string {
+	fake.withClientIDMutex.RLock()
+	defer fake.withClientIDMutex.RUnlock()
+	argsForCall := fake.withClientIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithClientSecret(arg1 string) {
+	fake.withClientSecretMutex.Lock()
+	fake.withClientSecretArgsForCall = append(fake.withClientSecretArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithClientSecretStub
+	fake.recordInvocation("WithClientSecret", []interface{}{arg1})
+	fake.withClientSecretMutex.Unlock()
+	if stub != nil {
+		fake.WithClientSecretStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithClientSecretCallCount() int {
+	fake.withClientSecretMutex.RLock()
+	defer fake.withClientSecretMutex.RUnlock()
+	return len(fake.withClientSecretArgsForCall)
+}
+
+func (fake *FakeClient) WithClientSecretCalls(stub func(string)) {
+	fake.withClientSecretMutex.Lock()
+	defer fake.withClientSecretMutex.Unlock()
+	fake.WithClientSecretStub = stub
+}
+
+func (fake *FakeClient) WithClientSecretArgsForCall(i int) string {
+	fake.withClientSecretMutex.RLock()
+	defer fake.withClientSecretMutex.RUnlock()
+	argsForCall := fake.withClientSecretArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithFederatedTokenFile(arg1 string) {
+	fake.withFederatedTokenFileMutex.Lock()
+	fake.withFederatedTokenFileArgsForCall = append(fake.withFederatedTokenFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithFederatedTokenFileStub
+	fake.recordInvocation("WithFederatedTokenFile", []interface{}{arg1})
+	fake.withFederatedTokenFileMutex.Unlock()
+	if stub != nil {
+		fake.WithFederatedTokenFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithFederatedTokenFileCallCount() int {
+	fake.withFederatedTokenFileMutex.RLock()
+	defer fake.withFederatedTokenFileMutex.RUnlock()
+	return len(fake.withFederatedTokenFileArgsForCall)
+}
+
+func (fake *FakeClient) WithFederatedTokenFileCalls(stub func(string)) {
+	fake.withFederatedTokenFileMutex.Lock()
+	defer fake.withFederatedTokenFileMutex.Unlock()
+	fake.WithFederatedTokenFileStub = stub
+}
+
+func (fake *FakeClient) WithFederatedTokenFileArgsForCall(i int) string {
+	fake.withFederatedTokenFileMutex.RLock()
+	defer fake.withFederatedTokenFileMutex.RUnlock()
+	argsForCall := fake.withFederatedTokenFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithServerApplicationID(arg1 string) {
+	fake.withServerApplicationIDMutex.Lock()
+	fake.withServerApplicationIDArgsForCall = append(fake.withServerApplicationIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithServerApplicationIDStub
+	fake.recordInvocation("WithServerApplicationID", []interface{}{arg1})
+	fake.withServerApplicationIDMutex.Unlock()
+	if stub != nil {
+		fake.WithServerApplicationIDStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithServerApplicationIDCallCount() int {
+	fake.withServerApplicationIDMutex.RLock()
+	defer fake.withServerApplicationIDMutex.RUnlock()
+	return len(fake.withServerApplicationIDArgsForCall)
+}
+
+func (fake *FakeClient) WithServerApplicationIDCalls(stub func(string)) {
+	fake.withServerApplicationIDMutex.Lock()
+	defer fake.withServerApplicationIDMutex.Unlock()
+	fake.WithServerApplicationIDStub = stub
+}
+
+func (fake *FakeClient) WithServerApplicationIDArgsForCall(i int) string {
+	fake.withServerApplicationIDMutex.RLock()
+	defer fake.withServerApplicationIDMutex.RUnlock()
+	argsForCall := fake.withServerApplicationIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithTenantID(arg1 string) {
+	fake.withTenantIDMutex.Lock()
+	fake.withTenantIDArgsForCall = append(fake.withTenantIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithTenantIDStub
+	fake.recordInvocation("WithTenantID", []interface{}{arg1})
+	fake.withTenantIDMutex.Unlock()
+	if stub != nil {
+		fake.WithTenantIDStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTenantIDCallCount() int {
+	fake.withTenantIDMutex.RLock()
+	defer fake.withTenantIDMutex.RUnlock()
+	return len(fake.withTenantIDArgsForCall)
+}
+
+func (fake *FakeClient) WithTenantIDCalls(stub func(string)) {
+	fake.withTenantIDMutex.Lock()
+	defer fake.withTenantIDMutex.Unlock()
+	fake.WithTenantIDStub = stub
+}
+
+func (fake *FakeClient) WithTenantIDArgsForCall(i int) string {
+	fake.withTenantIDMutex.RLock()
+	defer fake.withTenantIDMutex.RUnlock()
+	argsForCall := fake.withTenantIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	fake.withAuthorityHostMutex.RLock()
+	defer fake.withAuthorityHostMutex.RUnlock()
+	fake.withClientCertificateMutex.RLock()
+	defer fake.withClientCertificateMutex.RUnlock()
+	fake.withClientIDMutex.RLock()
+	defer fake.withClientIDMutex.RUnlock()
+	fake.withClientSecretMutex.RLock()
+	defer fake.withClientSecretMutex.RUnlock()
+	fake.withFederatedTokenFileMutex.RLock()
+	defer fake.withFederatedTokenFileMutex.RUnlock()
+	fake.withServerApplicationIDMutex.RLock()
+	defer fake.withServerApplicationIDMutex.RUnlock()
+	fake.withTenantIDMutex.RLock()
+	defer fake.withTenantIDMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ azure.Client = new(FakeClient)
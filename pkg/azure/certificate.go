@@ -0,0 +1,123 @@
+package azure
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"time"
+)
+
+var (
+	errCertificateNotFound = errors.New("error reading client certificate: no certificate found")
+	errPrivateKeyNotFound  = errors.New("error reading client certificate: no RSA private key found")
+)
+
+// readCertificate reads a PEM file containing a client certificate and
+// its RSA private key.
+func readCertificate(path string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		cert *x509.Certificate
+		key  *rsa.PrivateKey
+	)
+
+	for {
+		var block *pem.Block
+
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			if cert == nil {
+				cert, err = x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+		case "PRIVATE KEY":
+			k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if rsaKey, ok := k.(*rsa.PrivateKey); ok {
+				key = rsaKey
+			}
+		}
+	}
+
+	if cert == nil {
+		return nil, nil, errCertificateNotFound
+	}
+
+	if key == nil {
+		return nil, nil, errPrivateKeyNotFound
+	}
+
+	return cert, key, nil
+}
+
+// newClientAssertion builds a JWT signed by the client certificate's key
+// that proves possession of the certificate to the token endpoint.
+func newClientAssertion(cert *x509.Certificate, key *rsa.PrivateKey, clientID, audience string) (string, error) {
+	thumbprint := sha1.Sum(cert.Raw)
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": audience,
+		"iss": clientID,
+		"sub": clientID,
+		"jti": hex.EncodeToString(jti),
+		"nbf": now.Unix(),
+		"exp": now.Add(10 * time.Minute).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
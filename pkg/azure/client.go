@@ -0,0 +1,165 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultServerApplicationID is the application ID of the AKS AAD
+	// server shared by all AKS managed AAD clusters.
+	DefaultServerApplicationID = "6dae42f8-4368-4678-94ff-3960e28e3630"
+	defaultAuthorityHost       = "https://login.microsoftonline.com"
+	clientAssertionType        = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+)
+
+var (
+	errNotFoundFormat      = "error getting token: %s"
+	errCredentialsNotFound = errors.New("error getting token: no client secret, client certificate or federated token file configured")
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	NewToken(context.Context) (Token, error)
+	WithAuthorityHost(string)
+	WithTenantID(string)
+	WithClientID(string)
+	WithClientSecret(string)
+	WithClientCertificate(string)
+	WithFederatedTokenFile(string)
+	WithServerApplicationID(string)
+}
+
+func NewClient() Client {
+	return &client{
+		authorityHost:       defaultAuthorityHost,
+		serverApplicationID: DefaultServerApplicationID,
+		c:                   &http.Client{},
+	}
+}
+
+type client struct {
+	authorityHost       string
+	tenantID            string
+	clientID            string
+	clientSecret        string
+	clientCertificate   string
+	federatedTokenFile  string
+	serverApplicationID string
+	c                   *http.Client
+}
+
+func (c *client) WithAuthorityHost(authorityHost string) {
+	c.authorityHost = strings.TrimSuffix(authorityHost, "/")
+}
+
+func (c *client) WithTenantID(tenantID string) {
+	c.tenantID = tenantID
+}
+
+func (c *client) WithClientID(clientID string) {
+	c.clientID = clientID
+}
+
+func (c *client) WithClientSecret(clientSecret string) {
+	c.clientSecret = clientSecret
+}
+
+// WithClientCertificate sets the path to a PEM file containing the
+// client certificate and its private key.
+func (c *client) WithClientCertificate(clientCertificate string) {
+	c.clientCertificate = clientCertificate
+}
+
+// WithFederatedTokenFile sets the path to the projected service account
+// token used for workload identity federation.
+func (c *client) WithFederatedTokenFile(federatedTokenFile string) {
+	c.federatedTokenFile = federatedTokenFile
+}
+
+func (c *client) WithServerApplicationID(serverApplicationID string) {
+	c.serverApplicationID = serverApplicationID
+}
+
+// NewToken requests an access token for the AKS server application using
+// the client credentials grant. A client secret takes precedence over a
+// client certificate, which takes precedence over a federated token.
+func (c *client) NewToken(ctx context.Context) (Token, error) {
+	t := Token{}
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", c.authorityHost, c.tenantID)
+
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+	data.Set("client_id", c.clientID)
+	data.Set("scope", c.serverApplicationID+"/.default")
+
+	switch {
+	case c.clientSecret != "":
+		data.Set("client_secret", c.clientSecret)
+	case c.clientCertificate != "":
+		cert, key, err := readCertificate(c.clientCertificate)
+		if err != nil {
+			return t, err
+		}
+
+		assertion, err := newClientAssertion(cert, key, c.clientID, tokenURL)
+		if err != nil {
+			return t, err
+		}
+
+		data.Set("client_assertion_type", clientAssertionType)
+		data.Set("client_assertion", assertion)
+	case c.federatedTokenFile != "":
+		// Read the file on every request since it is rotated by the kubelet.
+		b, err := ioutil.ReadFile(c.federatedTokenFile)
+		if err != nil {
+			return t, err
+		}
+
+		data.Set("client_assertion_type", clientAssertionType)
+		data.Set("client_assertion", strings.TrimSpace(string(b)))
+	default:
+		return t, errCredentialsNotFound
+	}
+
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return t, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.c.Do(req)
+	if err != nil {
+		return t, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		return t, fmt.Errorf(errNotFoundFormat, res.Status)
+	}
+
+	tr := tokenResponse{}
+
+	err = json.NewDecoder(res.Body).Decode(&tr)
+	if err != nil {
+		return t, err
+	}
+
+	t.Token = tr.AccessToken
+	t.ExpiresAt = time.Now().In(time.UTC).Add(time.Duration(tr.ExpiresIn) * time.Second)
+
+	return t, nil
+}
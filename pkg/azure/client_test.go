@@ -0,0 +1,255 @@
+package azure_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/azure"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const payloadToken = `{
+  "token_type": "Bearer",
+  "expires_in": 3599,
+  "access_token": "fake-azure-token"
+}`
+
+var _ = Describe("Client", func() {
+	var (
+		server *ghttp.Server
+		client Client
+		dir    string
+		t      Token
+		err    error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		dir, _ = ioutil.TempDir("", "arcade-azure")
+		client = NewClient()
+		client.WithAuthorityHost(server.URL())
+		client.WithTenantID("test-tenant")
+		client.WithClientID("test-client")
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			t, err = client.NewToken(context.Background())
+		})
+
+		When("no credentials are configured", func() {
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: no client secret, client certificate or federated token file configured"))
+			})
+		})
+
+		When("the server is not reachable", func() {
+			BeforeEach(func() {
+				client.WithClientSecret("test-secret")
+				server.Close()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the response is not 200", func() {
+			BeforeEach(func() {
+				client.WithClientSecret("test-secret")
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusUnauthorized, nil),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: 401 Unauthorized"))
+			})
+		})
+
+		When("the response is invalid", func() {
+			BeforeEach(func() {
+				client.WithClientSecret("test-secret")
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{;'iuiuiu`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("invalid character ';' looking for beginning of object key string"))
+			})
+		})
+
+		When("using a client secret", func() {
+			BeforeEach(func() {
+				client.WithClientSecret("test-secret")
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/test-tenant/oauth2/v2.0/token"),
+					ghttp.VerifyContentType("application/x-www-form-urlencoded"),
+					ghttp.VerifyForm(map[string][]string{
+						"grant_type":    {"client_credentials"},
+						"client_id":     {"test-client"},
+						"client_secret": {"test-secret"},
+						"scope":         {"6dae42f8-4368-4678-94ff-3960e28e3630/.default"},
+					}),
+					ghttp.RespondWith(http.StatusOK, payloadToken),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-azure-token"))
+				Expect(t.ExpiresAt).To(BeTemporally("~", time.Now().Add(3599*time.Second), 5*time.Second))
+			})
+		})
+
+		When("the server application ID is set", func() {
+			BeforeEach(func() {
+				client.WithClientSecret("test-secret")
+				client.WithServerApplicationID("custom-app")
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/test-tenant/oauth2/v2.0/token"),
+					ghttp.VerifyForm(map[string][]string{
+						"scope": {"custom-app/.default"},
+					}),
+					ghttp.RespondWith(http.StatusOK, payloadToken),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-azure-token"))
+			})
+		})
+
+		When("using a client certificate", func() {
+			var key *rsa.PrivateKey
+
+			BeforeEach(func() {
+				var path string
+				key, path = writeCertificate(dir)
+				client.WithClientCertificate(path)
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/test-tenant/oauth2/v2.0/token"),
+					ghttp.VerifyForm(map[string][]string{
+						"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+					}),
+					func(w http.ResponseWriter, r *http.Request) {
+						parts := strings.Split(r.FormValue("client_assertion"), ".")
+						Expect(parts).To(HaveLen(3))
+
+						digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+						signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+						Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature)).To(Succeed())
+
+						claims := map[string]interface{}{}
+						b, _ := base64.RawURLEncoding.DecodeString(parts[1])
+						Expect(json.Unmarshal(b, &claims)).To(Succeed())
+						Expect(claims["iss"]).To(Equal("test-client"))
+						Expect(claims["sub"]).To(Equal("test-client"))
+						Expect(claims["aud"]).To(Equal(server.URL() + "/test-tenant/oauth2/v2.0/token"))
+					},
+					ghttp.RespondWith(http.StatusOK, payloadToken),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-azure-token"))
+			})
+		})
+
+		When("the client certificate does not exist", func() {
+			BeforeEach(func() {
+				client.WithClientCertificate(filepath.Join(dir, "missing.pem"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the client certificate has no private key", func() {
+			BeforeEach(func() {
+				path := filepath.Join(dir, "cert.pem")
+				_ = ioutil.WriteFile(path, []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"), 0600)
+				client.WithClientCertificate(path)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("using a federated token file", func() {
+			BeforeEach(func() {
+				path := filepath.Join(dir, "token")
+				_ = ioutil.WriteFile(path, []byte("fake-federated-token\n"), 0600)
+				client.WithFederatedTokenFile(path)
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/test-tenant/oauth2/v2.0/token"),
+					ghttp.VerifyForm(map[string][]string{
+						"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+						"client_assertion":      {"fake-federated-token"},
+					}),
+					ghttp.RespondWith(http.StatusOK, payloadToken),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-azure-token"))
+			})
+		})
+	})
+})
+
+// writeCertificate writes a self-signed certificate and its private key
+// to a PEM file in dir.
+func writeCertificate(dir string) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).To(BeNil())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "arcade"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+
+	b := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	b = append(b, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})...)
+
+	path := filepath.Join(dir, "cert.pem")
+	Expect(ioutil.WriteFile(path, b, 0600)).To(Succeed())
+
+	return key, path
+}
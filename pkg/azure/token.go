@@ -0,0 +1,16 @@
+package azure
+
+import "time"
+
+type Token struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// tokenResponse is the response of the Microsoft identity platform
+// token endpoint.
+type tokenResponse struct {
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	AccessToken string `json:"access_token"`
+}
@@ -0,0 +1,29 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+// NewTokenProvider returns a provider.TokenProvider that creates AKS
+// access tokens with c.
+func NewTokenProvider(c Client) provider.TokenProvider {
+	return &tokenProvider{c: c}
+}
+
+type tokenProvider struct {
+	c Client
+}
+
+func (tp *tokenProvider) NewToken(ctx context.Context) (provider.Token, error) {
+	t, err := tp.c.NewToken(ctx)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	return provider.Token{
+		Token:     t.Token,
+		ExpiresAt: t.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,299 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const errEnvNotSetFormat = "environment variable %s referenced in %s not set"
+
+// envRegexp matches the ${VAR} references expanded in a config file.
+var envRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Config declares the provider instances arcade serves tokens for.
+type Config struct {
+	// APIKeys are accepted in addition to ARCADE_API_KEY.
+	APIKeys   []APIKey   `yaml:"apiKeys"`
+	Providers []Provider `yaml:"providers"`
+}
+
+// APIKey is a named API key. Names let providers treat callers
+// differently, for example to downscope their tokens.
+type APIKey struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+// Provider is a named instance of a provider type. Only the block
+// matching Type is used.
+type Provider struct {
+	Name       string     `yaml:"name"`
+	Type       string     `yaml:"type"`
+	Google     Google     `yaml:"google"`
+	Rancher    Rancher    `yaml:"rancher"`
+	AWS        AWS        `yaml:"aws"`
+	Azure      Azure      `yaml:"azure"`
+	Kubernetes Kubernetes `yaml:"kubernetes"`
+	Vault      Vault      `yaml:"vault"`
+	OIDC       OIDC       `yaml:"oidc"`
+	Exec       Exec       `yaml:"exec"`
+	// Clusters are the clusters the instance's tokens are valid for. They
+	// are used to build kubeconfigs.
+	Clusters []Cluster `yaml:"clusters"`
+}
+
+type Cluster struct {
+	Name   string `yaml:"name"`
+	Server string `yaml:"server"`
+	// CAFile is the path to the PEM encoded CA of the cluster.
+	CAFile string `yaml:"caFile"`
+	// CAData is the base64 encoded PEM CA of the cluster, as found in
+	// a kubeconfig's certificate-authority-data.
+	CAData                string `yaml:"caData"`
+	InsecureSkipTLSVerify bool   `yaml:"insecureSkipTLSVerify"`
+}
+
+type Google struct {
+	// CredentialsFile is a service account key file or an external
+	// account (Workload Identity Federation) configuration file. It is
+	// re-read when it changes, so it can be a mounted secret that is
+	// rotated. Application Default Credentials are used when it is not set.
+	CredentialsFile string `yaml:"credentialsFile"`
+	// CredentialsType is the type the credentials file must have,
+	// service_account or external_account. Any type is accepted if it is
+	// not set.
+	CredentialsType string `yaml:"credentialsType"`
+	// ExpirySkew is how long before their expiry tokens are refreshed,
+	// for example 5m.
+	ExpirySkew time.Duration `yaml:"expirySkew"`
+	// ImpersonateServiceAccount is the email of a service account to get
+	// tokens for instead of the account arcade runs as.
+	ImpersonateServiceAccount string   `yaml:"impersonateServiceAccount"`
+	Delegates                 []string `yaml:"delegates"`
+	// Scopes are the OAuth scopes of access tokens. Defaults to the
+	// cloud-platform scope.
+	Scopes []string `yaml:"scopes"`
+	// AllowedScopes are the scopes callers may request per request.
+	AllowedScopes []string `yaml:"allowedScopes"`
+	// AllowedServiceAccounts are the service accounts callers may
+	// impersonate, or name as delegates, per request.
+	AllowedServiceAccounts []string `yaml:"allowedServiceAccounts"`
+	// AccessBoundaries downscope the access tokens of callers using the
+	// API keys they name.
+	AccessBoundaries []AccessBoundary `yaml:"accessBoundaries"`
+}
+
+// AccessBoundary is a Credential Access Boundary applied to the tokens of
+// callers using an API key.
+type AccessBoundary struct {
+	// APIKey is the name of the API key.
+	APIKey string               `yaml:"apiKey"`
+	Rules  []AccessBoundaryRule `yaml:"rules"`
+}
+
+type AccessBoundaryRule struct {
+	AvailableResource     string                 `yaml:"availableResource"`
+	AvailablePermissions  []string               `yaml:"availablePermissions"`
+	AvailabilityCondition *AvailabilityCondition `yaml:"availabilityCondition"`
+}
+
+type AvailabilityCondition struct {
+	Expression  string `yaml:"expression"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
+type Rancher struct {
+	// URL is the login URL of an auth provider, or the base URL of the
+	// Rancher server if AuthProvider is set.
+	URL          string `yaml:"url"`
+	AuthProvider string `yaml:"authProvider"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+	// APIKey, token-xxxxx:secret, creates derived tokens instead of logging
+	// in with Username and Password. APIKeyFile is read instead if it is set.
+	APIKey     string `yaml:"apiKey"`
+	APIKeyFile string `yaml:"apiKeyFile"`
+	// TTL is the requested lifetime of tokens. It is required with an API
+	// key.
+	TTL time.Duration `yaml:"ttl"`
+	// Description is a template of the description of tokens, for example
+	// arcade/{{.Hostname}}/{{.Provider}}.
+	Description string `yaml:"description"`
+	// RevokeOnShutdown deletes every token arcade created in Rancher when
+	// it shuts down.
+	RevokeOnShutdown bool `yaml:"revokeOnShutdown"`
+	// TokensFile keeps the IDs of the tokens arcade created in Rancher, so
+	// they can be listed and deleted after arcade restarts.
+	TokensFile string `yaml:"tokensFile"`
+	// MaxCacheAge is how long tokens that never expire are cached.
+	MaxCacheAge time.Duration `yaml:"maxCacheAge"`
+	// ValidationInterval is how often cached tokens are checked against
+	// Rancher, so tokens revoked there are replaced early.
+	ValidationInterval time.Duration `yaml:"validationInterval"`
+	// CAFile is a PEM bundle of the CAs of the Rancher server, read again
+	// when it changes. CertFile and KeyFile are a client certificate for
+	// servers that require mutual TLS.
+	CAFile                string `yaml:"caFile"`
+	CertFile              string `yaml:"certFile"`
+	KeyFile               string `yaml:"keyFile"`
+	InsecureSkipTLSVerify bool   `yaml:"insecureSkipTLSVerify"`
+	// ProxyURL is the proxy of requests to Rancher. The proxy environment
+	// variables are used if it is not set.
+	ProxyURL string `yaml:"proxyURL"`
+	// Timeout is the time limit of each request to Rancher.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+type AWS struct {
+	ClusterName string `yaml:"clusterName"`
+	Region      string `yaml:"region"`
+	STSEndpoint string `yaml:"stsEndpoint"`
+}
+
+type Azure struct {
+	TenantID              string `yaml:"tenantID"`
+	ClientID              string `yaml:"clientID"`
+	ClientSecret          string `yaml:"clientSecret"`
+	ClientCertificatePath string `yaml:"clientCertificatePath"`
+	FederatedTokenFile    string `yaml:"federatedTokenFile"`
+	AuthorityHost         string `yaml:"authorityHost"`
+	ServerApplicationID   string `yaml:"serverApplicationID"`
+}
+
+type Kubernetes struct {
+	URL               string `yaml:"url"`
+	Namespace         string `yaml:"namespace"`
+	ServiceAccount    string `yaml:"serviceAccount"`
+	Audience          string `yaml:"audience"`
+	ExpirationSeconds int64  `yaml:"expirationSeconds"`
+	BearerTokenFile   string `yaml:"bearerTokenFile"`
+	CAFile            string `yaml:"caFile"`
+}
+
+type Vault struct {
+	Addr                string `yaml:"addr"`
+	Role                string `yaml:"role"`
+	KubernetesNamespace string `yaml:"kubernetesNamespace"`
+	TTL                 string `yaml:"ttl"`
+	MountPath           string `yaml:"mountPath"`
+	AuthMethod          string `yaml:"authMethod"`
+	AuthMountPath       string `yaml:"authMountPath"`
+	AuthRole            string `yaml:"authRole"`
+	JWTFile             string `yaml:"jwtFile"`
+	RoleID              string `yaml:"roleID"`
+	SecretID            string `yaml:"secretID"`
+}
+
+type OIDC struct {
+	IssuerURL    string `yaml:"issuerURL"`
+	ClientID     string `yaml:"clientID"`
+	ClientSecret string `yaml:"clientSecret"`
+	RefreshToken string `yaml:"refreshToken"`
+	// RefreshTokenFile holds the refresh token instead of RefreshToken.
+	// Refresh tokens rotated by the issuer are written back to it.
+	RefreshTokenFile string `yaml:"refreshTokenFile"`
+}
+
+type Exec struct {
+	Command    string   `yaml:"command"`
+	Args       []string `yaml:"args"`
+	Env        []string `yaml:"env"`
+	APIVersion string   `yaml:"apiVersion"`
+}
+
+// Load reads a config file. Environment variables referenced as ${VAR}
+// in string values are expanded so secrets do not need to be written to
+// the file. Any other $ is kept as is.
+func Load(path string) (Config, error) {
+	c := Config{}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+
+	err = yaml.UnmarshalStrict(b, &c)
+	if err != nil {
+		return c, err
+	}
+
+	// Values are expanded after parsing, so a value containing YAML syntax,
+	// such as # or a newline, cannot change the structure of the file.
+	err = expandEnv(path, reflect.ValueOf(&c).Elem())
+	if err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// expandEnv replaces the ${VAR} references in the strings of v with the
+// value of VAR. It fails if VAR is not set.
+func expandEnv(path string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		if !v.CanSet() {
+			return nil
+		}
+
+		s, err := expandEnvString(path, v.String())
+		if err != nil {
+			return err
+		}
+
+		v.SetString(s)
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return expandEnv(path, v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if err := expandEnv(path, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := expandEnv(path, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+
+			if err := expandEnv(path, value); err != nil {
+				return err
+			}
+
+			v.SetMapIndex(key, value)
+		}
+	}
+
+	return nil
+}
+
+func expandEnvString(path, s string) (string, error) {
+	var err error
+
+	expanded := envRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envRegexp.FindStringSubmatch(ref)[1]
+
+		value, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf(errEnvNotSetFormat, name, path)
+		}
+
+		return value
+	})
+
+	return expanded, err
+}
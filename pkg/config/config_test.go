@@ -0,0 +1,353 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const payloadConfig = `apiKeys:
+- name: artifacts
+  key: ${ARCADE_TEST_RANCHER_PASSWORD}
+providers:
+- name: rancher-prod
+  type: rancher
+  rancher:
+    url: https://rancher-prod.example.com/v3-public/localProviders/local?action=login
+    username: arcade
+    password: ${ARCADE_TEST_RANCHER_PASSWORD}
+  clusters:
+  - name: prod-east
+    server: https://rancher-prod.example.com/k8s/clusters/c-abc12
+    caData: LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t
+- name: gcp-ci
+  type: google
+  google:
+    credentialsFile: /secrets/gcp-ci.json
+    allowedServiceAccounts:
+    - deploy@arcade-test.iam.gserviceaccount.com
+    accessBoundaries:
+    - apiKey: artifacts
+      rules:
+      - availableResource: //storage.googleapis.com/projects/_/buckets/artifacts
+        availablePermissions:
+        - inRole:roles/storage.objectViewer
+        availabilityCondition:
+          expression: resource.name.startsWith('projects/_/buckets/artifacts/objects/releases/')
+- name: plugin
+  type: exec
+  exec:
+    command: kubelogin
+    args:
+    - get-token
+    - --server-id=6dae42f8-4368-4678-94ff-3960e28e3630
+`
+
+var _ = Describe("Config", func() {
+	var (
+		dir  string
+		path string
+		c    Config
+		err  error
+	)
+
+	BeforeEach(func() {
+		dir, _ = ioutil.TempDir("", "arcade-config")
+		path = filepath.Join(dir, "config.yaml")
+		_ = ioutil.WriteFile(path, []byte(payloadConfig), 0600)
+		os.Setenv("ARCADE_TEST_RANCHER_PASSWORD", "test-pass")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("ARCADE_TEST_RANCHER_PASSWORD")
+	})
+
+	Describe("#Load", func() {
+		JustBeforeEach(func() {
+			c, err = Load(path)
+		})
+
+		When("the file does not exist", func() {
+			BeforeEach(func() {
+				path = filepath.Join(dir, "missing.yaml")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the file has an unknown field", func() {
+			BeforeEach(func() {
+				_ = ioutil.WriteFile(path, []byte("providers:\n- name: a\n  type: google\n  bogus: true\n"), 0600)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("it succeeds", func() {
+			It("reads every provider", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers).To(HaveLen(3))
+				Expect(c.Providers[0].Name).To(Equal("rancher-prod"))
+				Expect(c.Providers[0].Type).To(Equal("rancher"))
+				Expect(c.Providers[0].Rancher.Username).To(Equal("arcade"))
+				Expect(c.Providers[1].Google.CredentialsFile).To(Equal("/secrets/gcp-ci.json"))
+				Expect(c.Providers[2].Exec.Args).To(Equal([]string{"get-token", "--server-id=6dae42f8-4368-4678-94ff-3960e28e3630"}))
+			})
+
+			It("reads the clusters of a provider", func() {
+				Expect(c.Providers[0].Clusters).To(HaveLen(1))
+				Expect(c.Providers[0].Clusters[0].Name).To(Equal("prod-east"))
+				Expect(c.Providers[0].Clusters[0].Server).To(Equal("https://rancher-prod.example.com/k8s/clusters/c-abc12"))
+				Expect(c.Providers[0].Clusters[0].CAData).To(Equal("LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t"))
+			})
+
+			It("reads the API keys", func() {
+				Expect(c.APIKeys).To(Equal([]APIKey{{Name: "artifacts", Key: "test-pass"}}))
+			})
+
+			It("reads the service accounts a google provider may impersonate", func() {
+				Expect(c.Providers[1].Google.AllowedServiceAccounts).To(Equal([]string{"deploy@arcade-test.iam.gserviceaccount.com"}))
+			})
+
+			It("reads the access boundaries of a google provider", func() {
+				boundaries := c.Providers[1].Google.AccessBoundaries
+				Expect(boundaries).To(HaveLen(1))
+				Expect(boundaries[0].APIKey).To(Equal("artifacts"))
+				Expect(boundaries[0].Rules).To(HaveLen(1))
+				Expect(boundaries[0].Rules[0].AvailableResource).To(Equal("//storage.googleapis.com/projects/_/buckets/artifacts"))
+				Expect(boundaries[0].Rules[0].AvailablePermissions).To(Equal([]string{"inRole:roles/storage.objectViewer"}))
+				Expect(boundaries[0].Rules[0].AvailabilityCondition.Expression).To(HavePrefix("resource.name.startsWith"))
+			})
+
+			It("expands environment variables", func() {
+				Expect(c.Providers[0].Rancher.Password).To(Equal("test-pass"))
+			})
+		})
+
+		When("a value contains a $", func() {
+			BeforeEach(func() {
+				_ = ioutil.WriteFile(path, []byte("providers:\n- name: a\n  type: rancher\n  rancher:\n    username: $HOME\n    password: pa$word-${ARCADE_TEST_RANCHER_PASSWORD}-$$\n"), 0600)
+			})
+
+			It("expands only ${VAR} references", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers[0].Rancher.Username).To(Equal("$HOME"))
+				Expect(c.Providers[0].Rancher.Password).To(Equal("pa$word-test-pass-$$"))
+			})
+		})
+
+		When("an environment variable contains YAML syntax", func() {
+			secret := "abc #def: 'ghi\" \n    username: evil"
+
+			BeforeEach(func() {
+				_ = ioutil.WriteFile(path, []byte("providers:\n- name: a\n  type: rancher\n  rancher:\n    username: arcade\n    password: ${ARCADE_TEST_SECRET}\n"), 0600)
+				os.Setenv("ARCADE_TEST_SECRET", secret)
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("ARCADE_TEST_SECRET")
+			})
+
+			It("keeps the value as is", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers[0].Rancher.Password).To(Equal(secret))
+				Expect(c.Providers[0].Rancher.Username).To(Equal("arcade"))
+			})
+		})
+
+		When("a referenced environment variable is not set", func() {
+			BeforeEach(func() {
+				_ = ioutil.WriteFile(path, []byte("providers:\n- name: a\n  type: rancher\n  rancher:\n    password: ${ARCADE_TEST_UNSET}\n"), 0600)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("environment variable ARCADE_TEST_UNSET referenced in " + path + " not set"))
+			})
+		})
+	})
+
+	Describe("#FromEnv", func() {
+		BeforeEach(func() {
+			os.Setenv("RANCHER_ENABLED", "TRUE")
+			os.Setenv("RANCHER_URL", "https://rancher.example.com")
+			os.Setenv("RANCHER_USERNAME", "arcade")
+			os.Setenv("RANCHER_PASSWORD", "test-pass")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("RANCHER_ENABLED")
+			os.Unsetenv("RANCHER_URL")
+			os.Unsetenv("RANCHER_USERNAME")
+			os.Unsetenv("RANCHER_PASSWORD")
+		})
+
+		JustBeforeEach(func() {
+			c, err = FromEnv()
+		})
+
+		When("a required variable is not set", func() {
+			BeforeEach(func() {
+				os.Unsetenv("RANCHER_USERNAME")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("RANCHER_USERNAME not set"))
+			})
+		})
+
+		When("a number is invalid", func() {
+			BeforeEach(func() {
+				os.Setenv("KUBERNETES_ENABLED", "TRUE")
+				os.Setenv("KUBERNETES_NAMESPACE", "spinnaker")
+				os.Setenv("KUBERNETES_SERVICE_ACCOUNT", "spinnaker")
+				os.Setenv("KUBERNETES_EXPIRATION_SECONDS", "an hour")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("KUBERNETES_ENABLED")
+				os.Unsetenv("KUBERNETES_NAMESPACE")
+				os.Unsetenv("KUBERNETES_SERVICE_ACCOUNT")
+				os.Unsetenv("KUBERNETES_EXPIRATION_SECONDS")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("KUBERNETES_EXPIRATION_SECONDS is not valid"))
+			})
+		})
+
+		When("rancher uses an API key", func() {
+			BeforeEach(func() {
+				os.Unsetenv("RANCHER_USERNAME")
+				os.Unsetenv("RANCHER_PASSWORD")
+				os.Setenv("RANCHER_API_KEY_FILE", "/secrets/rancher/api-key")
+				os.Setenv("RANCHER_TTL", "2h")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("RANCHER_API_KEY_FILE")
+				os.Unsetenv("RANCHER_TTL")
+			})
+
+			It("does not require a username and password", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers[1].Rancher.APIKeyFile).To(Equal("/secrets/rancher/api-key"))
+				Expect(c.Providers[1].Rancher.TTL).To(Equal(2 * time.Hour))
+			})
+		})
+
+		When("rancher tokens are revoked on shutdown", func() {
+			BeforeEach(func() {
+				os.Setenv("RANCHER_REVOKE_ON_SHUTDOWN", "TRUE")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("RANCHER_REVOKE_ON_SHUTDOWN")
+			})
+
+			It("sets it", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers[1].Rancher.RevokeOnShutdown).To(BeTrue())
+			})
+		})
+
+		When("rancher tokens file is set", func() {
+			BeforeEach(func() {
+				os.Setenv("RANCHER_TOKENS_FILE", "/var/lib/arcade/rancher-tokens")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("RANCHER_TOKENS_FILE")
+			})
+
+			It("sets it", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers[1].Rancher.TokensFile).To(Equal("/var/lib/arcade/rancher-tokens"))
+			})
+		})
+
+		When("rancher token caching is set", func() {
+			BeforeEach(func() {
+				os.Setenv("RANCHER_MAX_CACHE_AGE", "8h")
+				os.Setenv("RANCHER_VALIDATION_INTERVAL", "1m")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("RANCHER_MAX_CACHE_AGE")
+				os.Unsetenv("RANCHER_VALIDATION_INTERVAL")
+			})
+
+			It("sets it", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers[1].Rancher.MaxCacheAge).To(Equal(8 * time.Hour))
+				Expect(c.Providers[1].Rancher.ValidationInterval).To(Equal(time.Minute))
+			})
+		})
+
+		When("rancher uses a custom CA and proxy", func() {
+			BeforeEach(func() {
+				os.Setenv("RANCHER_CA_FILE", "/secrets/rancher/ca.crt")
+				os.Setenv("RANCHER_CERT_FILE", "/secrets/rancher/tls.crt")
+				os.Setenv("RANCHER_KEY_FILE", "/secrets/rancher/tls.key")
+				os.Setenv("RANCHER_PROXY_URL", "http://proxy.example.com:3128")
+				os.Setenv("RANCHER_TIMEOUT", "15s")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("RANCHER_CA_FILE")
+				os.Unsetenv("RANCHER_CERT_FILE")
+				os.Unsetenv("RANCHER_KEY_FILE")
+				os.Unsetenv("RANCHER_PROXY_URL")
+				os.Unsetenv("RANCHER_TIMEOUT")
+			})
+
+			It("sets them", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers[1].Rancher.CAFile).To(Equal("/secrets/rancher/ca.crt"))
+				Expect(c.Providers[1].Rancher.CertFile).To(Equal("/secrets/rancher/tls.crt"))
+				Expect(c.Providers[1].Rancher.KeyFile).To(Equal("/secrets/rancher/tls.key"))
+				Expect(c.Providers[1].Rancher.ProxyURL).To(Equal("http://proxy.example.com:3128"))
+				Expect(c.Providers[1].Rancher.Timeout).To(Equal(15 * time.Second))
+				Expect(c.Providers[1].Rancher.InsecureSkipTLSVerify).To(BeFalse())
+			})
+		})
+
+		When("rancher timeout is not valid", func() {
+			BeforeEach(func() {
+				os.Setenv("RANCHER_TIMEOUT", "soon")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("RANCHER_TIMEOUT")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("RANCHER_TIMEOUT is not valid"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("names each provider after its type", func() {
+				Expect(err).To(BeNil())
+				Expect(c.Providers).To(HaveLen(2))
+				Expect(c.Providers[0].Name).To(Equal("google"))
+				Expect(c.Providers[1].Name).To(Equal("rancher"))
+				Expect(c.Providers[1].Rancher.URL).To(Equal("https://rancher.example.com"))
+			})
+		})
+	})
+})
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+var (
+	errNotSetFormat   = "%s not set"
+	errInvalidFormat  = "%s is not valid: %s"
+	errEnvUnsupported = "%s %s is not supported"
+)
+
+// FromEnv returns the providers configured with environment variables.
+// Each enabled provider is named after its type. Google is always enabled.
+func FromEnv() (Config, error) {
+	e := &env{}
+
+	c := Config{
+		Providers: []Provider{
+			{
+				Name: provider.TypeGoogle,
+				Type: provider.TypeGoogle,
+				Google: Google{
+					CredentialsFile:           os.Getenv("GOOGLE_CREDENTIALS_FILE"),
+					CredentialsType:           os.Getenv("GOOGLE_CREDENTIALS_TYPE"),
+					ExpirySkew:                e.getDuration("GOOGLE_EXPIRY_SKEW"),
+					ImpersonateServiceAccount: os.Getenv("GOOGLE_IMPERSONATE_SERVICE_ACCOUNT"),
+					Delegates:                 strings.Fields(os.Getenv("GOOGLE_DELEGATES")),
+					Scopes:                    strings.Fields(os.Getenv("GOOGLE_SCOPES")),
+					AllowedScopes:             strings.Fields(os.Getenv("GOOGLE_ALLOWED_SCOPES")),
+					AllowedServiceAccounts:    strings.Fields(os.Getenv("GOOGLE_ALLOWED_SERVICE_ACCOUNTS")),
+				},
+			},
+		},
+	}
+
+	if os.Getenv("RANCHER_ENABLED") == "TRUE" {
+		r := Rancher{
+			URL:                   e.mustGet("RANCHER_URL"),
+			AuthProvider:          os.Getenv("RANCHER_AUTH_PROVIDER"),
+			APIKey:                os.Getenv("RANCHER_API_KEY"),
+			APIKeyFile:            os.Getenv("RANCHER_API_KEY_FILE"),
+			TTL:                   e.getDuration("RANCHER_TTL"),
+			Description:           os.Getenv("RANCHER_DESCRIPTION"),
+			RevokeOnShutdown:      os.Getenv("RANCHER_REVOKE_ON_SHUTDOWN") == "TRUE",
+			TokensFile:            os.Getenv("RANCHER_TOKENS_FILE"),
+			MaxCacheAge:           e.getDuration("RANCHER_MAX_CACHE_AGE"),
+			ValidationInterval:    e.getDuration("RANCHER_VALIDATION_INTERVAL"),
+			CAFile:                os.Getenv("RANCHER_CA_FILE"),
+			CertFile:              os.Getenv("RANCHER_CERT_FILE"),
+			KeyFile:               os.Getenv("RANCHER_KEY_FILE"),
+			ProxyURL:              os.Getenv("RANCHER_PROXY_URL"),
+			Timeout:               e.getDuration("RANCHER_TIMEOUT"),
+			InsecureSkipTLSVerify: os.Getenv("RANCHER_INSECURE_SKIP_TLS_VERIFY") == "TRUE",
+		}
+
+		// A username and password are only needed to log in without an
+		// API key.
+		if r.APIKey == "" && r.APIKeyFile == "" {
+			r.Username = e.mustGet("RANCHER_USERNAME")
+			r.Password = e.mustGet("RANCHER_PASSWORD")
+		}
+
+		c.Providers = append(c.Providers, Provider{
+			Name:    provider.TypeRancher,
+			Type:    provider.TypeRancher,
+			Rancher: r,
+		})
+	}
+
+	if os.Getenv("AWS_ENABLED") == "TRUE" {
+		c.Providers = append(c.Providers, Provider{
+			Name: provider.TypeAWS,
+			Type: provider.TypeAWS,
+			AWS: AWS{
+				ClusterName: e.mustGet("AWS_CLUSTER_NAME"),
+				Region:      os.Getenv("AWS_REGION"),
+				STSEndpoint: os.Getenv("AWS_STS_ENDPOINT"),
+			},
+		})
+	}
+
+	if os.Getenv("AZURE_ENABLED") == "TRUE" {
+		c.Providers = append(c.Providers, Provider{
+			Name: provider.TypeAzure,
+			Type: provider.TypeAzure,
+			Azure: Azure{
+				TenantID:              e.mustGet("AZURE_TENANT_ID"),
+				ClientID:              e.mustGet("AZURE_CLIENT_ID"),
+				ClientSecret:          os.Getenv("AZURE_CLIENT_SECRET"),
+				ClientCertificatePath: os.Getenv("AZURE_CLIENT_CERTIFICATE_PATH"),
+				FederatedTokenFile:    os.Getenv("AZURE_FEDERATED_TOKEN_FILE"),
+				AuthorityHost:         os.Getenv("AZURE_AUTHORITY_HOST"),
+				ServerApplicationID:   os.Getenv("AZURE_SERVER_APPLICATION_ID"),
+			},
+		})
+	}
+
+	if os.Getenv("KUBERNETES_ENABLED") == "TRUE" {
+		c.Providers = append(c.Providers, Provider{
+			Name: provider.TypeKubernetes,
+			Type: provider.TypeKubernetes,
+			Kubernetes: Kubernetes{
+				URL:               os.Getenv("KUBERNETES_URL"),
+				Namespace:         e.mustGet("KUBERNETES_NAMESPACE"),
+				ServiceAccount:    e.mustGet("KUBERNETES_SERVICE_ACCOUNT"),
+				Audience:          os.Getenv("KUBERNETES_AUDIENCE"),
+				ExpirationSeconds: e.getInt64("KUBERNETES_EXPIRATION_SECONDS"),
+				BearerTokenFile:   os.Getenv("KUBERNETES_BEARER_TOKEN_FILE"),
+				CAFile:            os.Getenv("KUBERNETES_CA_FILE"),
+			},
+		})
+	}
+
+	if os.Getenv("VAULT_ENABLED") == "TRUE" {
+		v := Vault{
+			Addr:                e.mustGet("VAULT_ADDR"),
+			Role:                e.mustGet("VAULT_ROLE"),
+			KubernetesNamespace: e.mustGet("VAULT_KUBERNETES_NAMESPACE"),
+			TTL:                 os.Getenv("VAULT_TTL"),
+			MountPath:           os.Getenv("VAULT_MOUNT_PATH"),
+			AuthMethod:          os.Getenv("VAULT_AUTH_METHOD"),
+			AuthMountPath:       os.Getenv("VAULT_AUTH_MOUNT_PATH"),
+			JWTFile:             os.Getenv("VAULT_JWT_FILE"),
+		}
+
+		switch v.AuthMethod {
+		case "approle":
+			v.RoleID = e.mustGet("VAULT_ROLE_ID")
+			v.SecretID = e.mustGet("VAULT_SECRET_ID")
+		case "kubernetes", "":
+			v.AuthRole = e.mustGet("VAULT_AUTH_ROLE")
+		default:
+			e.err = fmt.Errorf(errEnvUnsupported, "VAULT_AUTH_METHOD", v.AuthMethod)
+		}
+
+		c.Providers = append(c.Providers, Provider{
+			Name:  provider.TypeVault,
+			Type:  provider.TypeVault,
+			Vault: v,
+		})
+	}
+
+	if os.Getenv("OIDC_ENABLED") == "TRUE" {
+		o := OIDC{
+			IssuerURL:        e.mustGet("OIDC_ISSUER_URL"),
+			ClientID:         e.mustGet("OIDC_CLIENT_ID"),
+			ClientSecret:     os.Getenv("OIDC_CLIENT_SECRET"),
+			RefreshTokenFile: os.Getenv("OIDC_REFRESH_TOKEN_FILE"),
+		}
+
+		// The refresh token is only required without a refresh token file.
+		if o.RefreshTokenFile == "" {
+			o.RefreshToken = e.mustGet("OIDC_REFRESH_TOKEN")
+		}
+
+		c.Providers = append(c.Providers, Provider{
+			Name: provider.TypeOIDC,
+			Type: provider.TypeOIDC,
+			OIDC: o,
+		})
+	}
+
+	if os.Getenv("EXEC_ENABLED") == "TRUE" {
+		c.Providers = append(c.Providers, Provider{
+			Name: provider.TypeExec,
+			Type: provider.TypeExec,
+			Exec: Exec{
+				Command:    e.mustGet("EXEC_COMMAND"),
+				Args:       strings.Fields(os.Getenv("EXEC_ARGS")),
+				Env:        strings.Fields(os.Getenv("EXEC_ENV")),
+				APIVersion: os.Getenv("EXEC_API_VERSION"),
+			},
+		})
+	}
+
+	return c, e.err
+}
+
+// env reads environment variables, keeping the first error so FromEnv
+// can read every variable before checking for one.
+type env struct {
+	err error
+}
+
+func (e *env) mustGet(key string) string {
+	s := os.Getenv(key)
+	if s == "" && e.err == nil {
+		e.err = fmt.Errorf(errNotSetFormat, key)
+	}
+
+	return s
+}
+
+func (e *env) getInt64(key string) int64 {
+	s := os.Getenv(key)
+	if s == "" {
+		return 0
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil && e.err == nil {
+		e.err = fmt.Errorf(errInvalidFormat, key, err.Error())
+	}
+
+	return i
+}
+
+func (e *env) getDuration(key string) time.Duration {
+	s := os.Getenv(key)
+	if s == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil && e.err == nil {
+		e.err = fmt.Errorf(errInvalidFormat, key, err.Error())
+	}
+
+	return d
+}
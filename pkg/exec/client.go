@@ -0,0 +1,120 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+)
+
+const (
+	// execInfoEnv passes the ExecCredential spec to the plugin, the same
+	// way kubectl does.
+	execInfoEnv = "KUBERNETES_EXEC_INFO"
+)
+
+var (
+	errCommandNotSet    = errors.New("error getting token: command not set")
+	errTokenNotFound    = errors.New("error getting token: no token in ExecCredential status")
+	errCommandFormat    = "error running %s: %s: %s"
+	errKindFormat       = "error getting token: unexpected kind %s"
+	errAPIVersionFormat = "error getting token: plugin returned apiVersion %s, expected %s"
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	NewToken(context.Context) (ExecCredential, error)
+	WithCommand(string)
+	WithArgs([]string)
+	WithEnv([]string)
+	WithAPIVersion(string)
+}
+
+func NewClient() Client {
+	return &client{
+		apiVersion: APIVersionV1,
+	}
+}
+
+type client struct {
+	command    string
+	args       []string
+	env        []string
+	apiVersion string
+}
+
+func (c *client) WithCommand(command string) {
+	c.command = command
+}
+
+func (c *client) WithArgs(args []string) {
+	c.args = args
+}
+
+// WithEnv sets additional environment variables, formatted as KEY=VALUE,
+// that are passed to the command along with arcade's own environment.
+func (c *client) WithEnv(env []string) {
+	c.env = env
+}
+
+func (c *client) WithAPIVersion(apiVersion string) {
+	c.apiVersion = apiVersion
+}
+
+// NewToken runs the configured credential plugin and parses the
+// ExecCredential it writes to stdout.
+func (c *client) NewToken(ctx context.Context) (ExecCredential, error) {
+	ec := ExecCredential{}
+
+	if c.command == "" {
+		return ec, errCommandNotSet
+	}
+
+	info, err := json.Marshal(ExecCredential{
+		APIVersion: c.apiVersion,
+		Kind:       kindExecCredential,
+		Spec: ExecCredentialSpec{
+			Interactive: false,
+		},
+	})
+	if err != nil {
+		return ec, err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := osexec.CommandContext(ctx, c.command, c.args...)
+	cmd.Env = append(os.Environ(), c.env...)
+	cmd.Env = append(cmd.Env, execInfoEnv+"="+string(info))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return ec, fmt.Errorf(errCommandFormat, c.command, err.Error(), strings.TrimSpace(stderr.String()))
+	}
+
+	err = json.Unmarshal(stdout.Bytes(), &ec)
+	if err != nil {
+		return ec, err
+	}
+
+	if ec.Kind != kindExecCredential {
+		return ec, fmt.Errorf(errKindFormat, ec.Kind)
+	}
+
+	if ec.APIVersion != c.apiVersion {
+		return ec, fmt.Errorf(errAPIVersionFormat, ec.APIVersion, c.apiVersion)
+	}
+
+	if ec.Status.Token == "" {
+		return ec, errTokenNotFound
+	}
+
+	return ec, nil
+}
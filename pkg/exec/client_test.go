@@ -0,0 +1,154 @@
+package exec_test
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const payloadExecCredential = `{
+  "kind": "ExecCredential",
+  "apiVersion": "client.authentication.k8s.io/v1",
+  "spec": {
+    "interactive": false
+  },
+  "status": {
+    "expirationTimestamp": "2021-03-25T11:38:18Z",
+    "token": "fake-exec-token"
+  }
+}`
+
+var _ = Describe("Client", func() {
+	var (
+		client Client
+		ec     ExecCredential
+		err    error
+	)
+
+	BeforeEach(func() {
+		client = NewClient()
+		client.WithCommand("sh")
+		client.WithArgs([]string{"-c", "echo '" + payloadExecCredential + "'"})
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			ec, err = client.NewToken(context.Background())
+		})
+
+		When("the command is not set", func() {
+			BeforeEach(func() {
+				client.WithCommand("")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: command not set"))
+			})
+		})
+
+		When("the command does not exist", func() {
+			BeforeEach(func() {
+				client.WithCommand("arcade-fake-plugin")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the command fails", func() {
+			BeforeEach(func() {
+				client.WithArgs([]string{"-c", "echo 'not logged in' >&2; exit 1"})
+			})
+
+			It("returns an error with the command's stderr", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error running sh: exit status 1: not logged in"))
+			})
+		})
+
+		When("the output is invalid", func() {
+			BeforeEach(func() {
+				client.WithArgs([]string{"-c", "echo '{;iuiuiu'"})
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("invalid character ';' looking for beginning of object key string"))
+			})
+		})
+
+		When("the output is not an ExecCredential", func() {
+			BeforeEach(func() {
+				client.WithArgs([]string{"-c", `echo '{"kind":"Config"}'`})
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: unexpected kind Config"))
+			})
+		})
+
+		When("the api version does not match", func() {
+			BeforeEach(func() {
+				client.WithAPIVersion(APIVersionV1beta1)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: plugin returned apiVersion client.authentication.k8s.io/v1, expected client.authentication.k8s.io/v1beta1"))
+			})
+		})
+
+		When("the output has no token", func() {
+			BeforeEach(func() {
+				client.WithArgs([]string{"-c", `echo '{"kind":"ExecCredential","apiVersion":"client.authentication.k8s.io/v1","status":{}}'`})
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: no token in ExecCredential status"))
+			})
+		})
+
+		When("the env is set", func() {
+			BeforeEach(func() {
+				client.WithEnv([]string{"ARCADE_TEST_TOKEN=env-exec-token"})
+				client.WithArgs([]string{"-c", `echo '{"kind":"ExecCredential","apiVersion":"client.authentication.k8s.io/v1","status":{"token":"'"$ARCADE_TEST_TOKEN"'"}}'`})
+			})
+
+			It("passes it to the command", func() {
+				Expect(err).To(BeNil())
+				Expect(ec.Status.Token).To(Equal("env-exec-token"))
+				Expect(ec.Status.ExpirationTimestamp.IsZero()).To(BeTrue())
+			})
+		})
+
+		When("the plugin reads KUBERNETES_EXEC_INFO", func() {
+			BeforeEach(func() {
+				client.WithArgs([]string{"-c", `echo '{"kind":"ExecCredential","apiVersion":"client.authentication.k8s.io/v1","status":{"token":"'"$(echo $KUBERNETES_EXEC_INFO | base64 | tr -d '\n')"'"}}'`})
+			})
+
+			It("passes the request spec", func() {
+				Expect(err).To(BeNil())
+				b, _ := base64.StdEncoding.DecodeString(ec.Status.Token)
+				Expect(string(b)).To(ContainSubstring(`"apiVersion":"client.authentication.k8s.io/v1"`))
+				Expect(string(b)).To(ContainSubstring(`"interactive":false`))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(ec.Status.Token).To(Equal("fake-exec-token"))
+				Expect(ec.Status.ExpirationTimestamp).To(Equal(time.Date(2021, 3, 25, 11, 38, 18, 0, time.UTC)))
+			})
+		})
+	})
+})
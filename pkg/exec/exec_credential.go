@@ -0,0 +1,32 @@
+package exec
+
+import "time"
+
+const (
+	// APIVersionV1 is the client.authentication.k8s.io version arcade
+	// requests from credential plugins by default.
+	APIVersionV1 = "client.authentication.k8s.io/v1"
+	// APIVersionV1beta1 is supported by older credential plugins.
+	APIVersionV1beta1  = "client.authentication.k8s.io/v1beta1"
+	kindExecCredential = "ExecCredential"
+)
+
+type ExecCredential struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Spec       ExecCredentialSpec   `json:"spec"`
+	Status     ExecCredentialStatus `json:"status"`
+}
+
+type ExecCredentialSpec struct {
+	Interactive bool `json:"interactive"`
+}
+
+type ExecCredentialStatus struct {
+	// ExpirationTimestamp is the zero time when the plugin did not
+	// return one.
+	ExpirationTimestamp   time.Time `json:"expirationTimestamp"`
+	Token                 string    `json:"token"`
+	ClientCertificateData string    `json:"clientCertificateData,omitempty"`
+	ClientKeyData         string    `json:"clientKeyData,omitempty"`
+}
@@ -0,0 +1,283 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/homedepot/arcade/pkg/exec"
+)
+
+type FakeClient struct {
+	NewTokenStub        func(context.Context) (exec.ExecCredential, error)
+	newTokenMutex       sync.RWMutex
+	newTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	newTokenReturns struct {
+		result1 exec.ExecCredential
+		result2 error
+	}
+	newTokenReturnsOnCall map[int]struct {
+		result1 exec.ExecCredential
+		result2 error
+	}
+	WithAPIVersionStub        func(string)
+	withAPIVersionMutex       sync.RWMutex
+	withAPIVersionArgsForCall []struct {
+		arg1 string
+	}
+	WithArgsStub        func([]string)
+	withArgsMutex       sync.RWMutex
+	withArgsArgsForCall []struct {
+		arg1 []string
+	}
+	WithCommandStub        func(string)
+	withCommandMutex       sync.RWMutex
+	withCommandArgsForCall []struct {
+		arg1 string
+	}
+	WithEnvStub        func([]string)
+	withEnvMutex       sync.RWMutex
+	withEnvArgsForCall []struct {
+		arg1 []string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) NewToken(arg1 context.Context) (exec.ExecCredential, error) {
+	fake.newTokenMutex.Lock()
+	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
+	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
+	fake.recordInvocation("NewToken", []interface{}{arg1})
+	fake.newTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewTokenCallCount() int {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	return len(fake.newTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewTokenCalls(stub func(context.Context) (exec.ExecCredential, error)) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = stub
+}
+
+func (fake *FakeClient) NewTokenArgsForCall(i int) context.Context {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	argsForCall := fake.newTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) NewTokenReturns(result1 exec.ExecCredential, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	fake.newTokenReturns = struct {
+		result1 exec.ExecCredential
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 exec.ExecCredential, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	if fake.newTokenReturnsOnCall == nil {
+		fake.newTokenReturnsOnCall = make(map[int]struct {
+			result1 exec.ExecCredential
+			result2 error
+		})
+	}
+	fake.newTokenReturnsOnCall[i] = struct {
+		result1 exec.ExecCredential
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WithAPIVersion(arg1 string) {
+	fake.withAPIVersionMutex.Lock()
+	fake.withAPIVersionArgsForCall = append(fake.withAPIVersionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAPIVersionStub
+	fake.recordInvocation("WithAPIVersion", []interface{}{arg1})
+	fake.withAPIVersionMutex.Unlock()
+	if stub != nil {
+		fake.WithAPIVersionStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAPIVersionCallCount() int {
+	fake.withAPIVersionMutex.RLock()
+	defer fake.withAPIVersionMutex.RUnlock()
+	return len(fake.withAPIVersionArgsForCall)
+}
+
+func (fake *FakeClient) WithAPIVersionCalls(stub func(string)) {
+	fake.withAPIVersionMutex.Lock()
+	defer fake.withAPIVersionMutex.Unlock()
+	fake.WithAPIVersionStub = stub
+}
+
+func (fake *FakeClient) WithAPIVersionArgsForCall(i int) string {
+	fake.withAPIVersionMutex.RLock()
+	defer fake.withAPIVersionMutex.RUnlock()
+	argsForCall := fake.withAPIVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithArgs(arg1 []string) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.withArgsMutex.Lock()
+	fake.withArgsArgsForCall = append(fake.withArgsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.WithArgsStub
+	fake.recordInvocation("WithArgs", []interface{}{arg1Copy})
+	fake.withArgsMutex.Unlock()
+	if stub != nil {
+		fake.WithArgsStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithArgsCallCount() int {
+	fake.withArgsMutex.RLock()
+	defer fake.withArgsMutex.RUnlock()
+	return len(fake.withArgsArgsForCall)
+}
+
+func (fake *FakeClient) WithArgsCalls(stub func([]string)) {
+	fake.withArgsMutex.Lock()
+	defer fake.withArgsMutex.Unlock()
+	fake.WithArgsStub = stub
+}
+
+func (fake *FakeClient) WithArgsArgsForCall(i int) []string {
+	fake.withArgsMutex.RLock()
+	defer fake.withArgsMutex.RUnlock()
+	argsForCall := fake.withArgsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithCommand(arg1 string) {
+	fake.withCommandMutex.Lock()
+	fake.withCommandArgsForCall = append(fake.withCommandArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithCommandStub
+	fake.recordInvocation("WithCommand", []interface{}{arg1})
+	fake.withCommandMutex.Unlock()
+	if stub != nil {
+		fake.WithCommandStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithCommandCallCount() int {
+	fake.withCommandMutex.RLock()
+	defer fake.withCommandMutex.RUnlock()
+	return len(fake.withCommandArgsForCall)
+}
+
+func (fake *FakeClient) WithCommandCalls(stub func(string)) {
+	fake.withCommandMutex.Lock()
+	defer fake.withCommandMutex.Unlock()
+	fake.WithCommandStub = stub
+}
+
+func (fake *FakeClient) WithCommandArgsForCall(i int) string {
+	fake.withCommandMutex.RLock()
+	defer fake.withCommandMutex.RUnlock()
+	argsForCall := fake.withCommandArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithEnv(arg1 []string) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.withEnvMutex.Lock()
+	fake.withEnvArgsForCall = append(fake.withEnvArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.WithEnvStub
+	fake.recordInvocation("WithEnv", []interface{}{arg1Copy})
+	fake.withEnvMutex.Unlock()
+	if stub != nil {
+		fake.WithEnvStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithEnvCallCount() int {
+	fake.withEnvMutex.RLock()
+	defer fake.withEnvMutex.RUnlock()
+	return len(fake.withEnvArgsForCall)
+}
+
+func (fake *FakeClient) WithEnvCalls(stub func([]string)) {
+	fake.withEnvMutex.Lock()
+	defer fake.withEnvMutex.Unlock()
+	fake.WithEnvStub = stub
+}
+
+func (fake *FakeClient) WithEnvArgsForCall(i int) []string {
+	fake.withEnvMutex.RLock()
+	defer fake.withEnvMutex.RUnlock()
+	argsForCall := fake.withEnvArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	fake.withAPIVersionMutex.RLock()
+	defer fake.withAPIVersionMutex.RUnlock()
+	fake.withArgsMutex.RLock()
+	defer fake.withArgsMutex.RUnlock()
+	fake.withCommandMutex.RLock()
+	defer fake.withCommandMutex.RUnlock()
+	fake.withEnvMutex.RLock()
+	defer fake.withEnvMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.Client = new(FakeClient)
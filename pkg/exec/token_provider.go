@@ -0,0 +1,48 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+const (
+	// expiration is how long a credential without an expiration
+	// timestamp is cached.
+	expiration = 1 * time.Minute
+)
+
+// NewTokenProvider returns a provider.TokenProvider that runs the
+// credential plugin configured on c.
+func NewTokenProvider(c Client) provider.TokenProvider {
+	return &tokenProvider{c: c}
+}
+
+type tokenProvider struct {
+	c Client
+}
+
+func (tp *tokenProvider) NewToken(ctx context.Context) (provider.Token, error) {
+	ec, err := tp.c.NewToken(ctx)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	t := provider.Token{
+		Token: ec.Status.Token,
+	}
+
+	// Credential plugins are not required to return an expiration
+	// timestamp. Credentials without one are cached briefly, so the plugin
+	// does not run on every request but a revoked credential is not served
+	// for long. The cache replaces tokens the expiry skew before they
+	// expire, so it is added.
+	if ec.Status.ExpirationTimestamp.IsZero() {
+		t.ExpiresAt = time.Now().In(time.UTC).Add(expiration + provider.DefaultExpirySkew)
+	} else {
+		t.ExpiresAt = ec.Status.ExpirationTimestamp
+	}
+
+	return t, nil
+}
@@ -0,0 +1,72 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/exec"
+	"github.com/homedepot/arcade/pkg/exec/execfakes"
+	"github.com/homedepot/arcade/pkg/provider"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenProvider", func() {
+	var (
+		fakeClient *execfakes.FakeClient
+		t          provider.Token
+		err        error
+	)
+
+	BeforeEach(func() {
+		fakeClient = &execfakes.FakeClient{}
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			t, err = NewTokenProvider(fakeClient).NewToken(context.Background())
+		})
+
+		When("the plugin fails", func() {
+			BeforeEach(func() {
+				fakeClient.NewTokenReturns(ExecCredential{}, errors.New("error running plugin"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the credential has no expiration timestamp", func() {
+			BeforeEach(func() {
+				fakeClient.NewTokenReturns(ExecCredential{
+					Status: ExecCredentialStatus{Token: "fake-exec-token"},
+				}, nil)
+			})
+
+			It("expires the token after a minute", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-exec-token"))
+				Expect(t.ExpiresAt).To(BeTemporally("~", time.Now().Add(2*time.Minute), 5*time.Second))
+			})
+		})
+
+		When("the credential has an expiration timestamp", func() {
+			var expiresAt time.Time
+
+			BeforeEach(func() {
+				expiresAt = time.Now().Add(time.Hour).In(time.UTC)
+				fakeClient.NewTokenReturns(ExecCredential{
+					Status: ExecCredentialStatus{Token: "fake-exec-token", ExpirationTimestamp: expiresAt},
+				}, nil)
+			})
+
+			It("expires the token a minute before it", func() {
+				Expect(err).To(BeNil())
+				Expect(t.ExpiresAt).To(Equal(expiresAt))
+			})
+		})
+	})
+})
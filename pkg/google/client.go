@@ -2,45 +2,278 @@ package google
 
 import (
 	"context"
+	"sync"
+	"time"
 
-	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
 const (
-	Key = "GoogleClient"
+	// DefaultExpirySkew is how long before its expiry a token is refreshed.
+	DefaultExpirySkew = 1 * time.Minute
 )
 
-var clientScopes = []string{
+// defaultScopes are the scopes of tokens when none are configured. The
+// IAM Service Account Credentials API is always called with them.
+var defaultScopes = []string{
 	"https://www.googleapis.com/auth/cloud-platform",
 }
 
 //go:generate counterfeiter . Client
 
 type Client interface {
-	NewToken() (string, error)
+	NewToken(context.Context) (*oauth2.Token, error)
+	NewScopedToken(context.Context, []string) (*oauth2.Token, error)
+	NewImpersonatedToken(context.Context, Impersonation) (*oauth2.Token, error)
+	NewIDToken(context.Context, string) (IDToken, error)
+	NewImpersonatedIDToken(context.Context, Impersonation, string) (IDToken, error)
+	NewDownscopedToken(context.Context, *oauth2.Token, AccessBoundary) (*oauth2.Token, error)
+	WithCredentialsFile(string)
+	WithCredentialsType(string)
+	WithExpirySkew(time.Duration)
+	WithScopes([]string)
+	WithImpersonateServiceAccount(string)
+	WithDelegates([]string)
+	WithIAMCredentialsURL(string)
+	WithMetadataURL(string)
+	WithSTSURL(string)
 }
 
 func NewClient() Client {
-	return &client{}
+	return &client{
+		expirySkew:        DefaultExpirySkew,
+		scopes:            defaultScopes,
+		iamCredentialsURL: DefaultIAMCredentialsURL,
+		metadataURL:       DefaultMetadataURL,
+		stsURL:            DefaultSTSURL,
+	}
+}
+
+type client struct {
+	mux                       sync.Mutex
+	credentialsFile           string
+	credentialsType           string
+	credentials               []byte
+	expirySkew                time.Duration
+	scopes                    []string
+	impersonateServiceAccount string
+	delegates                 []string
+	iamCredentialsURL         string
+	metadataURL               string
+	stsURL                    string
+	baseTokenSource           oauth2.TokenSource
+	tokenSource               oauth2.TokenSource
+}
+
+// WithCredentialsFile sets a service account key file to use instead of
+// Application Default Credentials.
+func (c *client) WithCredentialsFile(credentialsFile string) {
+	c.credentialsFile = credentialsFile
+}
+
+// WithCredentialsType sets the type the credentials file must have, for
+// example CredentialsTypeExternalAccount. Any type is accepted if it is
+// not set.
+func (c *client) WithCredentialsType(credentialsType string) {
+	c.credentialsType = credentialsType
+}
+
+// WithExpirySkew sets how long before its expiry a token is refreshed.
+func (c *client) WithExpirySkew(expirySkew time.Duration) {
+	c.expirySkew = expirySkew
+}
+
+// WithScopes sets the OAuth scopes of the tokens NewToken returns. The
+// cloud-platform scope is used when scopes is empty.
+func (c *client) WithScopes(scopes []string) {
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+
+	c.scopes = scopes
+}
+
+// WithImpersonateServiceAccount sets a service account that NewToken
+// impersonates.
+func (c *client) WithImpersonateServiceAccount(impersonateServiceAccount string) {
+	c.impersonateServiceAccount = impersonateServiceAccount
+}
+
+// WithDelegates sets the delegation chain used to impersonate the
+// service account set with WithImpersonateServiceAccount.
+func (c *client) WithDelegates(delegates []string) {
+	c.delegates = delegates
+}
+
+func (c *client) WithIAMCredentialsURL(iamCredentialsURL string) {
+	c.iamCredentialsURL = iamCredentialsURL
+}
+
+func (c *client) WithMetadataURL(metadataURL string) {
+	c.metadataURL = metadataURL
+}
+
+func (c *client) WithSTSURL(stsURL string) {
+	c.stsURL = stsURL
+}
+
+// NewToken returns a token that is valid for at least the expiry skew.
+// Tokens are reused until then, so google is only called when the
+// current token is about to expire.
+func (c *client) NewToken(ctx context.Context) (*oauth2.Token, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	err := c.reloadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.tokenSource == nil {
+		base, err := c.base()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case c.impersonateServiceAccount != "":
+			c.tokenSource = oauth2.ReuseTokenSource(nil, c.impersonatedTokenSource(context.Background(), base, Impersonation{
+				TargetPrincipal: c.impersonateServiceAccount,
+				Delegates:       c.delegates,
+				Scopes:          c.scopes,
+			}))
+		case equal(c.scopes, defaultScopes):
+			c.tokenSource = base
+		default:
+			c.tokenSource, err = c.newTokenSource(context.Background(), c.credentials, c.scopes)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Expiry.IsZero() && !time.Now().Add(c.expirySkew).Before(token.Expiry) {
+		// The token source only refreshes tokens seconds before they
+		// expire. Wrapping it with an empty token makes it refresh now.
+		c.tokenSource = oauth2.ReuseTokenSource(&oauth2.Token{}, c.tokenSource)
+
+		token, err = c.tokenSource.Token()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return token, nil
 }
 
-type client struct{}
+// NewScopedToken returns a new token with the given scopes, impersonating
+// the configured service account if there is one.
+func (c *client) NewScopedToken(ctx context.Context, scopes []string) (*oauth2.Token, error) {
+	if c.impersonateServiceAccount != "" {
+		return c.NewImpersonatedToken(ctx, Impersonation{
+			TargetPrincipal: c.impersonateServiceAccount,
+			Delegates:       c.delegates,
+			Scopes:          scopes,
+		})
+	}
+
+	c.mux.Lock()
+	err := c.reloadCredentials()
+	credentials := c.credentials
+	c.mux.Unlock()
 
-func (client) NewToken() (string, error) {
-	tokenSource, err := google.DefaultTokenSource(context.Background(), clientScopes...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	token, err := tokenSource.Token()
+	tokenSource, err := c.newTokenSource(ctx, credentials, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenSource.Token()
+}
+
+// NewImpersonatedToken returns a new token for a service account. Scopes
+// default to the scopes set with WithScopes.
+func (c *client) NewImpersonatedToken(ctx context.Context, i Impersonation) (*oauth2.Token, error) {
+	c.mux.Lock()
+	base, err := c.base()
+	c.mux.Unlock()
+
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token.AccessToken, nil
+	if len(i.Scopes) == 0 {
+		i.Scopes = c.scopes
+	}
+
+	return c.impersonatedTokenSource(ctx, base, i).Token()
 }
 
-func Instance(c *gin.Context) Client {
-	return c.MustGet(Key).(Client)
+func (c *client) impersonatedTokenSource(ctx context.Context, base oauth2.TokenSource, i Impersonation) oauth2.TokenSource {
+	return &impersonatedTokenSource{
+		ctx:           ctx,
+		base:          base,
+		url:           c.iamCredentialsURL,
+		impersonation: i,
+	}
+}
+
+// base returns the token source of the credentials arcade runs with,
+// creating it on first use. c.mux must be held.
+func (c *client) base() (oauth2.TokenSource, error) {
+	err := c.reloadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.baseTokenSource == nil {
+		// The token source outlives the request, so it must not use its context.
+		tokenSource, err := c.newTokenSource(context.Background(), c.credentials, defaultScopes)
+		if err != nil {
+			return nil, err
+		}
+
+		c.baseTokenSource = tokenSource
+	}
+
+	return c.baseTokenSource, nil
+}
+
+// newTokenSource returns a token source for credentials, which may be a
+// service account key or an external account configuration, or for
+// Application Default Credentials if credentials is empty.
+func (c *client) newTokenSource(ctx context.Context, credentials []byte, scopes []string) (oauth2.TokenSource, error) {
+	if len(credentials) == 0 {
+		return google.DefaultTokenSource(ctx, scopes...)
+	}
+
+	creds, err := google.CredentialsFromJSON(ctx, credentials, scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return creds.TokenSource, nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
 }
@@ -0,0 +1,313 @@
+package google_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/google"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server *ghttp.Server
+		dir    string
+		client Client
+		token  *oauth2.Token
+		err    error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		dir, _ = ioutil.TempDir("", "arcade-google")
+		client = NewClient()
+		client.WithCredentialsFile(writeServiceAccountKey(dir, server.URL()+"/token"))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			token, err = client.NewToken(context.Background())
+		})
+
+		When("the credentials file does not exist", func() {
+			BeforeEach(func() {
+				client.WithCredentialsFile(filepath.Join(dir, "missing.json"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the token endpoint returns an error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusBadRequest, `{"error":"invalid_grant"}`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/token"),
+						ghttp.RespondWith(http.StatusOK, `{"access_token":"fake-google-token","token_type":"Bearer","expires_in":3600}`,
+							http.Header{"Content-Type": []string{"application/json"}}),
+					),
+				)
+			})
+
+			It("returns the token with its expiry", func() {
+				Expect(err).To(BeNil())
+				Expect(token.AccessToken).To(Equal("fake-google-token"))
+				Expect(token.Expiry).To(BeTemporally("~", time.Now().Add(time.Hour), 10*time.Second))
+			})
+
+			It("reuses the token", func() {
+				token, err = client.NewToken(context.Background())
+				Expect(err).To(BeNil())
+				Expect(token.AccessToken).To(Equal("fake-google-token"))
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		When("the token expires within the expiry skew", func() {
+			BeforeEach(func() {
+				client.WithExpirySkew(5 * time.Minute)
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{"access_token":"short-lived-token","token_type":"Bearer","expires_in":120}`,
+						http.Header{"Content-Type": []string{"application/json"}}),
+					ghttp.RespondWith(http.StatusOK, `{"access_token":"fresh-token","token_type":"Bearer","expires_in":3600}`,
+						http.Header{"Content-Type": []string{"application/json"}}),
+				)
+			})
+
+			It("refreshes the token", func() {
+				Expect(err).To(BeNil())
+				Expect(token.AccessToken).To(Equal("fresh-token"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+})
+
+var _ = Describe("Scopes", func() {
+	var (
+		server *ghttp.Server
+		dir    string
+		client Client
+		token  *oauth2.Token
+		err    error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		dir, _ = ioutil.TempDir("", "arcade-google")
+		client = NewClient()
+		client.WithCredentialsFile(writeServiceAccountKey(dir, server.URL()+"/token"))
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				verifyAssertionScope("https://www.googleapis.com/auth/devstorage.read_only"),
+				ghttp.RespondWith(http.StatusOK, `{"access_token":"scoped-token","token_type":"Bearer","expires_in":3600}`,
+					http.Header{"Content-Type": []string{"application/json"}}),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("#NewToken", func() {
+		BeforeEach(func() {
+			client.WithScopes([]string{"https://www.googleapis.com/auth/devstorage.read_only"})
+		})
+
+		JustBeforeEach(func() {
+			token, err = client.NewToken(context.Background())
+		})
+
+		It("requests the configured scopes", func() {
+			Expect(err).To(BeNil())
+			Expect(token.AccessToken).To(Equal("scoped-token"))
+		})
+	})
+
+	Describe("#NewScopedToken", func() {
+		JustBeforeEach(func() {
+			token, err = client.NewScopedToken(context.Background(), []string{"https://www.googleapis.com/auth/devstorage.read_only"})
+		})
+
+		It("requests the scopes", func() {
+			Expect(err).To(BeNil())
+			Expect(token.AccessToken).To(Equal("scoped-token"))
+		})
+	})
+})
+
+var _ = Describe("Impersonation", func() {
+	var (
+		server *ghttp.Server
+		dir    string
+		client Client
+		token  *oauth2.Token
+		err    error
+		expiry time.Time
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		dir, _ = ioutil.TempDir("", "arcade-google")
+		expiry = time.Now().Add(time.Hour).Truncate(time.Second).In(time.UTC)
+		client = NewClient()
+		client.WithCredentialsFile(writeServiceAccountKey(dir, server.URL()+"/token"))
+		client.WithIAMCredentialsURL(server.URL())
+		server.RouteToHandler(http.MethodPost, "/token",
+			ghttp.RespondWith(http.StatusOK, `{"access_token":"base-token","token_type":"Bearer","expires_in":3600}`,
+				http.Header{"Content-Type": []string{"application/json"}}),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("#NewImpersonatedToken", func() {
+		JustBeforeEach(func() {
+			token, err = client.NewImpersonatedToken(context.Background(), Impersonation{
+				TargetPrincipal: "deploy@arcade-test.iam.gserviceaccount.com",
+				Delegates:       []string{"delegate@arcade-test.iam.gserviceaccount.com"},
+			})
+		})
+
+		When("the caller is not allowed to impersonate the service account", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusForbidden, `{"error":{"code":403,"status":"PERMISSION_DENIED"}}`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error impersonating deploy@arcade-test.iam.gserviceaccount.com: 403 Forbidden"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/projects/-/serviceAccounts/deploy@arcade-test.iam.gserviceaccount.com:generateAccessToken"),
+						ghttp.VerifyHeaderKV("Authorization", "Bearer base-token"),
+						ghttp.VerifyJSON(`{
+							"delegates": ["projects/-/serviceAccounts/delegate@arcade-test.iam.gserviceaccount.com"],
+							"scope": ["https://www.googleapis.com/auth/cloud-platform"]
+						}`),
+						ghttp.RespondWith(http.StatusOK, `{"accessToken":"impersonated-token","expireTime":"`+expiry.Format(time.RFC3339)+`"}`),
+					),
+				)
+			})
+
+			It("returns the token of the service account", func() {
+				Expect(err).To(BeNil())
+				Expect(token.AccessToken).To(Equal("impersonated-token"))
+				Expect(token.Expiry).To(Equal(expiry))
+			})
+		})
+	})
+
+	Describe("#NewToken", func() {
+		BeforeEach(func() {
+			client.WithImpersonateServiceAccount("deploy@arcade-test.iam.gserviceaccount.com")
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/v1/projects/-/serviceAccounts/deploy@arcade-test.iam.gserviceaccount.com:generateAccessToken"),
+					ghttp.RespondWith(http.StatusOK, `{"accessToken":"impersonated-token","expireTime":"`+expiry.Format(time.RFC3339)+`"}`),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			token, err = client.NewToken(context.Background())
+		})
+
+		When("a service account to impersonate is configured", func() {
+			It("returns and reuses its token", func() {
+				Expect(err).To(BeNil())
+				Expect(token.AccessToken).To(Equal("impersonated-token"))
+				token, err = client.NewToken(context.Background())
+				Expect(err).To(BeNil())
+				Expect(token.AccessToken).To(Equal("impersonated-token"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+})
+
+// writeServiceAccountKey writes a service account key file that gets
+// tokens from tokenURI and returns its path.
+func writeServiceAccountKey(dir, tokenURI string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).To(BeNil())
+
+	b, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "arcade-test",
+		"private_key_id": "test-key-id",
+		"private_key": string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		"client_email": "arcade@arcade-test.iam.gserviceaccount.com",
+		"client_id":    "123456789",
+		"token_uri":    tokenURI,
+	})
+	Expect(err).To(BeNil())
+
+	path := filepath.Join(dir, "key.json")
+	Expect(ioutil.WriteFile(path, b, 0600)).To(Succeed())
+
+	return path
+}
+
+// verifyAssertionScope verifies the scope claim of the JWT assertion a
+// service account key sends to the token endpoint.
+func verifyAssertionScope(scope string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Expect(r.ParseForm()).To(Succeed())
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		Expect(parts).To(HaveLen(3))
+		b, err := base64.RawURLEncoding.DecodeString(parts[1])
+		Expect(err).To(BeNil())
+		claims := map[string]interface{}{}
+		Expect(json.Unmarshal(b, &claims)).To(Succeed())
+		Expect(claims["scope"]).To(Equal(scope))
+	}
+}
@@ -0,0 +1,59 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+const (
+	// CredentialsTypeServiceAccount is a service account key file.
+	CredentialsTypeServiceAccount = "service_account"
+	// CredentialsTypeExternalAccount is a Workload Identity Federation
+	// credential configuration file.
+	CredentialsTypeExternalAccount = "external_account"
+)
+
+var (
+	errCredentialsTypeFormat = "credentials file %s has type %q, expected %q"
+)
+
+// reloadCredentials reads the credentials file and drops the token sources
+// created with its previous content, so a mounted secret that is rotated
+// is picked up. c.mux must be held.
+func (c *client) reloadCredentials() error {
+	if c.credentialsFile == "" {
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(c.credentialsFile)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(b, c.credentials) {
+		return nil
+	}
+
+	if c.credentialsType != "" {
+		f := struct {
+			Type string `json:"type"`
+		}{}
+
+		err = json.Unmarshal(b, &f)
+		if err != nil {
+			return err
+		}
+
+		if f.Type != c.credentialsType {
+			return fmt.Errorf(errCredentialsTypeFormat, c.credentialsFile, f.Type, c.credentialsType)
+		}
+	}
+
+	c.credentials = b
+	c.baseTokenSource = nil
+	c.tokenSource = nil
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// DefaultSTSURL is the base URL of the Security Token Service API.
+	DefaultSTSURL = "https://sts.googleapis.com"
+
+	tokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	grantTypeExchange    = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+var (
+	errDownscopeFormat = "error downscoping token: %s"
+)
+
+// AccessBoundary is a Credential Access Boundary that limits the
+// permissions of a downscoped token to the rules.
+type AccessBoundary struct {
+	AccessBoundaryRules []AccessBoundaryRule `json:"accessBoundaryRules"`
+}
+
+type AccessBoundaryRule struct {
+	// AvailableResource is the full resource name of a bucket, for example
+	// //storage.googleapis.com/projects/_/buckets/my-bucket.
+	AvailableResource string `json:"availableResource"`
+	// AvailablePermissions are the IAM roles the token may use on the
+	// resource, prefixed with inRole:, for example
+	// inRole:roles/storage.objectViewer.
+	AvailablePermissions  []string               `json:"availablePermissions"`
+	AvailabilityCondition *AvailabilityCondition `json:"availabilityCondition,omitempty"`
+}
+
+// AvailabilityCondition further limits the objects a rule applies to
+// with a CEL expression.
+type AvailabilityCondition struct {
+	Expression  string `json:"expression"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type exchangeTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// NewDownscopedToken exchanges token at the Security Token Service for a
+// token limited to the access boundary. The downscoped token expires with
+// token unless the Security Token Service returns its own lifetime.
+func (c *client) NewDownscopedToken(ctx context.Context, token *oauth2.Token, boundary AccessBoundary) (*oauth2.Token, error) {
+	options, err := json.Marshal(struct {
+		AccessBoundary AccessBoundary `json:"accessBoundary"`
+	}{boundary})
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", grantTypeExchange)
+	form.Set("subject_token_type", tokenTypeAccessToken)
+	form.Set("requested_token_type", tokenTypeAccessToken)
+	form.Set("subject_token", token.AccessToken)
+	form.Set("options", string(options))
+
+	u := strings.TrimSuffix(c.stsURL, "/") + "/v1/token"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	b, err := do(http.DefaultClient, req)
+	if err != nil {
+		return nil, fmt.Errorf(errDownscopeFormat, err.Error())
+	}
+
+	etr := exchangeTokenResponse{}
+
+	err = json.Unmarshal(b, &etr)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := token.Expiry
+	if etr.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(etr.ExpiresIn) * time.Second)
+	}
+
+	return &oauth2.Token{
+		AccessToken: etr.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package google_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/google"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("Downscope", func() {
+	var (
+		server   *ghttp.Server
+		client   Client
+		source   *oauth2.Token
+		boundary AccessBoundary
+		token    *oauth2.Token
+		err      error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = NewClient()
+		client.WithSTSURL(server.URL())
+		source = &oauth2.Token{
+			AccessToken: "source-token",
+			Expiry:      time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+		boundary = AccessBoundary{
+			AccessBoundaryRules: []AccessBoundaryRule{
+				{
+					AvailableResource:    "//storage.googleapis.com/projects/_/buckets/artifacts",
+					AvailablePermissions: []string{"inRole:roles/storage.objectViewer"},
+					AvailabilityCondition: &AvailabilityCondition{
+						Expression: "resource.name.startsWith('projects/_/buckets/artifacts/objects/releases/')",
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#NewDownscopedToken", func() {
+		JustBeforeEach(func() {
+			token, err = client.NewDownscopedToken(context.Background(), source, boundary)
+		})
+
+		When("the exchange fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusBadRequest, `{"error":"invalid_request"}`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(HavePrefix("error downscoping token: 400 Bad Request"))
+			})
+		})
+
+		When("the response has no lifetime", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/token"),
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.ParseForm()).To(Succeed())
+							Expect(r.PostForm.Get("grant_type")).To(Equal("urn:ietf:params:oauth:grant-type:token-exchange"))
+							Expect(r.PostForm.Get("subject_token_type")).To(Equal("urn:ietf:params:oauth:token-type:access_token"))
+							Expect(r.PostForm.Get("requested_token_type")).To(Equal("urn:ietf:params:oauth:token-type:access_token"))
+							Expect(r.PostForm.Get("subject_token")).To(Equal("source-token"))
+							options := map[string]interface{}{}
+							Expect(json.Unmarshal([]byte(r.PostForm.Get("options")), &options)).To(Succeed())
+							Expect(options).To(HaveKey("accessBoundary"))
+							Expect(r.PostForm.Get("options")).To(ContainSubstring(`"availableResource":"//storage.googleapis.com/projects/_/buckets/artifacts"`))
+						},
+						ghttp.RespondWith(http.StatusOK, `{"access_token":"downscoped-token","issued_token_type":"urn:ietf:params:oauth:token-type:access_token","token_type":"Bearer"}`),
+					),
+				)
+			})
+
+			It("expires with the source token", func() {
+				Expect(err).To(BeNil())
+				Expect(token.AccessToken).To(Equal("downscoped-token"))
+				Expect(token.Expiry).To(Equal(source.Expiry))
+			})
+		})
+
+		When("the response has a lifetime", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{"access_token":"downscoped-token","token_type":"Bearer","expires_in":600}`),
+				)
+			})
+
+			It("expires with the lifetime", func() {
+				Expect(err).To(BeNil())
+				Expect(token.Expiry).To(BeTemporally("~", time.Now().Add(10*time.Minute), 5*time.Second))
+			})
+		})
+	})
+})
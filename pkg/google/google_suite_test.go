@@ -0,0 +1,13 @@
+package google_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGoogle(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Google Suite")
+}
@@ -2,42 +2,497 @@
 package googlefakes
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/homedepot/arcade/pkg/google"
+	"golang.org/x/oauth2"
 )
 
 type FakeClient struct {
-	NewTokenStub        func() (string, error)
+	NewDownscopedTokenStub        func(context.Context, *oauth2.Token, google.AccessBoundary) (*oauth2.Token, error)
+	newDownscopedTokenMutex       sync.RWMutex
+	newDownscopedTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 *oauth2.Token
+		arg3 google.AccessBoundary
+	}
+	newDownscopedTokenReturns struct {
+		result1 *oauth2.Token
+		result2 error
+	}
+	newDownscopedTokenReturnsOnCall map[int]struct {
+		result1 *oauth2.Token
+		result2 error
+	}
+	NewIDTokenStub        func(context.Context, string) (google.IDToken, error)
+	newIDTokenMutex       sync.RWMutex
+	newIDTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	newIDTokenReturns struct {
+		result1 google.IDToken
+		result2 error
+	}
+	newIDTokenReturnsOnCall map[int]struct {
+		result1 google.IDToken
+		result2 error
+	}
+	NewImpersonatedIDTokenStub        func(context.Context, google.Impersonation, string) (google.IDToken, error)
+	newImpersonatedIDTokenMutex       sync.RWMutex
+	newImpersonatedIDTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 google.Impersonation
+		arg3 string
+	}
+	newImpersonatedIDTokenReturns struct {
+		result1 google.IDToken
+		result2 error
+	}
+	newImpersonatedIDTokenReturnsOnCall map[int]struct {
+		result1 google.IDToken
+		result2 error
+	}
+	NewImpersonatedTokenStub        func(context.Context, google.Impersonation) (*oauth2.Token, error)
+	newImpersonatedTokenMutex       sync.RWMutex
+	newImpersonatedTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 google.Impersonation
+	}
+	newImpersonatedTokenReturns struct {
+		result1 *oauth2.Token
+		result2 error
+	}
+	newImpersonatedTokenReturnsOnCall map[int]struct {
+		result1 *oauth2.Token
+		result2 error
+	}
+	NewScopedTokenStub        func(context.Context, []string) (*oauth2.Token, error)
+	newScopedTokenMutex       sync.RWMutex
+	newScopedTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	newScopedTokenReturns struct {
+		result1 *oauth2.Token
+		result2 error
+	}
+	newScopedTokenReturnsOnCall map[int]struct {
+		result1 *oauth2.Token
+		result2 error
+	}
+	NewTokenStub        func(context.Context) (*oauth2.Token, error)
 	newTokenMutex       sync.RWMutex
 	newTokenArgsForCall []struct {
+		arg1 context.Context
 	}
 	newTokenReturns struct {
-		result1 string
+		result1 *oauth2.Token
 		result2 error
 	}
 	newTokenReturnsOnCall map[int]struct {
-		result1 string
+		result1 *oauth2.Token
 		result2 error
 	}
+	WithCredentialsFileStub        func(string)
+	withCredentialsFileMutex       sync.RWMutex
+	withCredentialsFileArgsForCall []struct {
+		arg1 string
+	}
+	WithCredentialsTypeStub        func(string)
+	withCredentialsTypeMutex       sync.RWMutex
+	withCredentialsTypeArgsForCall []struct {
+		arg1 string
+	}
+	WithDelegatesStub        func([]string)
+	withDelegatesMutex       sync.RWMutex
+	withDelegatesArgsForCall []struct {
+		arg1 []string
+	}
+	WithExpirySkewStub        func(time.Duration)
+	withExpirySkewMutex       sync.RWMutex
+	withExpirySkewArgsForCall []struct {
+		arg1 time.Duration
+	}
+	WithIAMCredentialsURLStub        func(string)
+	withIAMCredentialsURLMutex       sync.RWMutex
+	withIAMCredentialsURLArgsForCall []struct {
+		arg1 string
+	}
+	WithImpersonateServiceAccountStub        func(string)
+	withImpersonateServiceAccountMutex       sync.RWMutex
+	withImpersonateServiceAccountArgsForCall []struct {
+		arg1 string
+	}
+	WithMetadataURLStub        func(string)
+	withMetadataURLMutex       sync.RWMutex
+	withMetadataURLArgsForCall []struct {
+		arg1 string
+	}
+	WithSTSURLStub        func(string)
+	withSTSURLMutex       sync.RWMutex
+	withSTSURLArgsForCall []struct {
+		arg1 string
+	}
+	WithScopesStub        func([]string)
+	withScopesMutex       sync.RWMutex
+	withScopesArgsForCall []struct {
+		arg1 []string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeClient) NewToken() (string, error) {
+func (fake *FakeClient) NewDownscopedToken(arg1 context.Context, arg2 *oauth2.Token, arg3 google.AccessBoundary) (*oauth2.Token, error) {
+	fake.newDownscopedTokenMutex.Lock()
+	ret, specificReturn := fake.newDownscopedTokenReturnsOnCall[len(fake.newDownscopedTokenArgsForCall)]
+	fake.newDownscopedTokenArgsForCall = append(fake.newDownscopedTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 *oauth2.Token
+		arg3 google.AccessBoundary
+	}{arg1, arg2, arg3})
+	stub := fake.NewDownscopedTokenStub
+	fakeReturns := fake.newDownscopedTokenReturns
+	fake.recordInvocation("NewDownscopedToken", []interface{}{arg1, arg2, arg3})
+	fake.newDownscopedTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewDownscopedTokenCallCount() int {
+	fake.newDownscopedTokenMutex.RLock()
+	defer fake.newDownscopedTokenMutex.RUnlock()
+	return len(fake.newDownscopedTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewDownscopedTokenCalls(stub func(context.Context, *oauth2.Token, google.AccessBoundary) (*oauth2.Token, error)) {
+	fake.newDownscopedTokenMutex.Lock()
+	defer fake.newDownscopedTokenMutex.Unlock()
+	fake.NewDownscopedTokenStub = stub
+}
+
+func (fake *FakeClient) NewDownscopedTokenArgsForCall(i int) (context.Context, *oauth2.Token, google.AccessBoundary) {
+	fake.newDownscopedTokenMutex.RLock()
+	defer fake.newDownscopedTokenMutex.RUnlock()
+	argsForCall := fake.newDownscopedTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) NewDownscopedTokenReturns(result1 *oauth2.Token, result2 error) {
+	fake.newDownscopedTokenMutex.Lock()
+	defer fake.newDownscopedTokenMutex.Unlock()
+	fake.NewDownscopedTokenStub = nil
+	fake.newDownscopedTokenReturns = struct {
+		result1 *oauth2.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewDownscopedTokenReturnsOnCall(i int, result1 *oauth2.Token, result2 error) {
+	fake.newDownscopedTokenMutex.Lock()
+	defer fake.newDownscopedTokenMutex.Unlock()
+	fake.NewDownscopedTokenStub = nil
+	if fake.newDownscopedTokenReturnsOnCall == nil {
+		fake.newDownscopedTokenReturnsOnCall = make(map[int]struct {
+			result1 *oauth2.Token
+			result2 error
+		})
+	}
+	fake.newDownscopedTokenReturnsOnCall[i] = struct {
+		result1 *oauth2.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewIDToken(arg1 context.Context, arg2 string) (google.IDToken, error) {
+	fake.newIDTokenMutex.Lock()
+	ret, specificReturn := fake.newIDTokenReturnsOnCall[len(fake.newIDTokenArgsForCall)]
+	fake.newIDTokenArgsForCall = append(fake.newIDTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NewIDTokenStub
+	fakeReturns := fake.newIDTokenReturns
+	fake.recordInvocation("NewIDToken", []interface{}{arg1, arg2})
+	fake.newIDTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewIDTokenCallCount() int {
+	fake.newIDTokenMutex.RLock()
+	defer fake.newIDTokenMutex.RUnlock()
+	return len(fake.newIDTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewIDTokenCalls(stub func(context.Context, string) (google.IDToken, error)) {
+	fake.newIDTokenMutex.Lock()
+	defer fake.newIDTokenMutex.Unlock()
+	fake.NewIDTokenStub = stub
+}
+
+func (fake *FakeClient) NewIDTokenArgsForCall(i int) (context.Context, string) {
+	fake.newIDTokenMutex.RLock()
+	defer fake.newIDTokenMutex.RUnlock()
+	argsForCall := fake.newIDTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) NewIDTokenReturns(result1 google.IDToken, result2 error) {
+	fake.newIDTokenMutex.Lock()
+	defer fake.newIDTokenMutex.Unlock()
+	fake.NewIDTokenStub = nil
+	fake.newIDTokenReturns = struct {
+		result1 google.IDToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewIDTokenReturnsOnCall(i int, result1 google.IDToken, result2 error) {
+	fake.newIDTokenMutex.Lock()
+	defer fake.newIDTokenMutex.Unlock()
+	fake.NewIDTokenStub = nil
+	if fake.newIDTokenReturnsOnCall == nil {
+		fake.newIDTokenReturnsOnCall = make(map[int]struct {
+			result1 google.IDToken
+			result2 error
+		})
+	}
+	fake.newIDTokenReturnsOnCall[i] = struct {
+		result1 google.IDToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewImpersonatedIDToken(arg1 context.Context, arg2 google.Impersonation, arg3 string) (google.IDToken, error) {
+	fake.newImpersonatedIDTokenMutex.Lock()
+	ret, specificReturn := fake.newImpersonatedIDTokenReturnsOnCall[len(fake.newImpersonatedIDTokenArgsForCall)]
+	fake.newImpersonatedIDTokenArgsForCall = append(fake.newImpersonatedIDTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 google.Impersonation
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.NewImpersonatedIDTokenStub
+	fakeReturns := fake.newImpersonatedIDTokenReturns
+	fake.recordInvocation("NewImpersonatedIDToken", []interface{}{arg1, arg2, arg3})
+	fake.newImpersonatedIDTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewImpersonatedIDTokenCallCount() int {
+	fake.newImpersonatedIDTokenMutex.RLock()
+	defer fake.newImpersonatedIDTokenMutex.RUnlock()
+	return len(fake.newImpersonatedIDTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewImpersonatedIDTokenCalls(stub func(context.Context, google.Impersonation, string) (google.IDToken, error)) {
+	fake.newImpersonatedIDTokenMutex.Lock()
+	defer fake.newImpersonatedIDTokenMutex.Unlock()
+	fake.NewImpersonatedIDTokenStub = stub
+}
+
+func (fake *FakeClient) NewImpersonatedIDTokenArgsForCall(i int) (context.Context, google.Impersonation, string) {
+	fake.newImpersonatedIDTokenMutex.RLock()
+	defer fake.newImpersonatedIDTokenMutex.RUnlock()
+	argsForCall := fake.newImpersonatedIDTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) NewImpersonatedIDTokenReturns(result1 google.IDToken, result2 error) {
+	fake.newImpersonatedIDTokenMutex.Lock()
+	defer fake.newImpersonatedIDTokenMutex.Unlock()
+	fake.NewImpersonatedIDTokenStub = nil
+	fake.newImpersonatedIDTokenReturns = struct {
+		result1 google.IDToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewImpersonatedIDTokenReturnsOnCall(i int, result1 google.IDToken, result2 error) {
+	fake.newImpersonatedIDTokenMutex.Lock()
+	defer fake.newImpersonatedIDTokenMutex.Unlock()
+	fake.NewImpersonatedIDTokenStub = nil
+	if fake.newImpersonatedIDTokenReturnsOnCall == nil {
+		fake.newImpersonatedIDTokenReturnsOnCall = make(map[int]struct {
+			result1 google.IDToken
+			result2 error
+		})
+	}
+	fake.newImpersonatedIDTokenReturnsOnCall[i] = struct {
+		result1 google.IDToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewImpersonatedToken(arg1 context.Context, arg2 google.Impersonation) (*oauth2.Token, error) {
+	fake.newImpersonatedTokenMutex.Lock()
+	ret, specificReturn := fake.newImpersonatedTokenReturnsOnCall[len(fake.newImpersonatedTokenArgsForCall)]
+	fake.newImpersonatedTokenArgsForCall = append(fake.newImpersonatedTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 google.Impersonation
+	}{arg1, arg2})
+	stub := fake.NewImpersonatedTokenStub
+	fakeReturns := fake.newImpersonatedTokenReturns
+	fake.recordInvocation("NewImpersonatedToken", []interface{}{arg1, arg2})
+	fake.newImpersonatedTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewImpersonatedTokenCallCount() int {
+	fake.newImpersonatedTokenMutex.RLock()
+	defer fake.newImpersonatedTokenMutex.RUnlock()
+	return len(fake.newImpersonatedTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewImpersonatedTokenCalls(stub func(context.Context, google.Impersonation) (*oauth2.Token, error)) {
+	fake.newImpersonatedTokenMutex.Lock()
+	defer fake.newImpersonatedTokenMutex.Unlock()
+	fake.NewImpersonatedTokenStub = stub
+}
+
+func (fake *FakeClient) NewImpersonatedTokenArgsForCall(i int) (context.Context, google.Impersonation) {
+	fake.newImpersonatedTokenMutex.RLock()
+	defer fake.newImpersonatedTokenMutex.RUnlock()
+	argsForCall := fake.newImpersonatedTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) NewImpersonatedTokenReturns(result1 *oauth2.Token, result2 error) {
+	fake.newImpersonatedTokenMutex.Lock()
+	defer fake.newImpersonatedTokenMutex.Unlock()
+	fake.NewImpersonatedTokenStub = nil
+	fake.newImpersonatedTokenReturns = struct {
+		result1 *oauth2.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewImpersonatedTokenReturnsOnCall(i int, result1 *oauth2.Token, result2 error) {
+	fake.newImpersonatedTokenMutex.Lock()
+	defer fake.newImpersonatedTokenMutex.Unlock()
+	fake.NewImpersonatedTokenStub = nil
+	if fake.newImpersonatedTokenReturnsOnCall == nil {
+		fake.newImpersonatedTokenReturnsOnCall = make(map[int]struct {
+			result1 *oauth2.Token
+			result2 error
+		})
+	}
+	fake.newImpersonatedTokenReturnsOnCall[i] = struct {
+		result1 *oauth2.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewScopedToken(arg1 context.Context, arg2 []string) (*oauth2.Token, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.newScopedTokenMutex.Lock()
+	ret, specificReturn := fake.newScopedTokenReturnsOnCall[len(fake.newScopedTokenArgsForCall)]
+	fake.newScopedTokenArgsForCall = append(fake.newScopedTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.NewScopedTokenStub
+	fakeReturns := fake.newScopedTokenReturns
+	fake.recordInvocation("NewScopedToken", []interface{}{arg1, arg2Copy})
+	fake.newScopedTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewScopedTokenCallCount() int {
+	fake.newScopedTokenMutex.RLock()
+	defer fake.newScopedTokenMutex.RUnlock()
+	return len(fake.newScopedTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewScopedTokenCalls(stub func(context.Context, []string) (*oauth2.Token, error)) {
+	fake.newScopedTokenMutex.Lock()
+	defer fake.newScopedTokenMutex.Unlock()
+	fake.NewScopedTokenStub = stub
+}
+
+func (fake *FakeClient) NewScopedTokenArgsForCall(i int) (context.Context, []string) {
+	fake.newScopedTokenMutex.RLock()
+	defer fake.newScopedTokenMutex.RUnlock()
+	argsForCall := fake.newScopedTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) NewScopedTokenReturns(result1 *oauth2.Token, result2 error) {
+	fake.newScopedTokenMutex.Lock()
+	defer fake.newScopedTokenMutex.Unlock()
+	fake.NewScopedTokenStub = nil
+	fake.newScopedTokenReturns = struct {
+		result1 *oauth2.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewScopedTokenReturnsOnCall(i int, result1 *oauth2.Token, result2 error) {
+	fake.newScopedTokenMutex.Lock()
+	defer fake.newScopedTokenMutex.Unlock()
+	fake.NewScopedTokenStub = nil
+	if fake.newScopedTokenReturnsOnCall == nil {
+		fake.newScopedTokenReturnsOnCall = make(map[int]struct {
+			result1 *oauth2.Token
+			result2 error
+		})
+	}
+	fake.newScopedTokenReturnsOnCall[i] = struct {
+		result1 *oauth2.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewToken(arg1 context.Context) (*oauth2.Token, error) {
 	fake.newTokenMutex.Lock()
 	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
 	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
-	}{})
-	fake.recordInvocation("NewToken", []interface{}{})
+		arg1 context.Context
+	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
+	fake.recordInvocation("NewToken", []interface{}{arg1})
 	fake.newTokenMutex.Unlock()
-	if fake.NewTokenStub != nil {
-		return fake.NewTokenStub()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.newTokenReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -47,43 +502,376 @@ func (fake *FakeClient) NewTokenCallCount() int {
 	return len(fake.newTokenArgsForCall)
 }
 
-func (fake *FakeClient) NewTokenCalls(stub func() (string, error)) {
+func (fake *FakeClient) NewTokenCalls(stub func(context.Context) (*oauth2.Token, error)) {
 	fake.newTokenMutex.Lock()
 	defer fake.newTokenMutex.Unlock()
 	fake.NewTokenStub = stub
 }
 
-func (fake *FakeClient) NewTokenReturns(result1 string, result2 error) {
+func (fake *FakeClient) NewTokenArgsForCall(i int) context.Context {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	argsForCall := fake.newTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) NewTokenReturns(result1 *oauth2.Token, result2 error) {
 	fake.newTokenMutex.Lock()
 	defer fake.newTokenMutex.Unlock()
 	fake.NewTokenStub = nil
 	fake.newTokenReturns = struct {
-		result1 string
+		result1 *oauth2.Token
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 string, result2 error) {
+func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 *oauth2.Token, result2 error) {
 	fake.newTokenMutex.Lock()
 	defer fake.newTokenMutex.Unlock()
 	fake.NewTokenStub = nil
 	if fake.newTokenReturnsOnCall == nil {
 		fake.newTokenReturnsOnCall = make(map[int]struct {
-			result1 string
+			result1 *oauth2.Token
 			result2 error
 		})
 	}
 	fake.newTokenReturnsOnCall[i] = struct {
-		result1 string
+		result1 *oauth2.Token
 		result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeClient) WithCredentialsFile(arg1 string) {
+	fake.withCredentialsFileMutex.Lock()
+	fake.withCredentialsFileArgsForCall = append(fake.withCredentialsFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithCredentialsFileStub
+	fake.recordInvocation("WithCredentialsFile", []interface{}{arg1})
+	fake.withCredentialsFileMutex.Unlock()
+	if stub != nil {
+		fake.WithCredentialsFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithCredentialsFileCallCount() int {
+	fake.withCredentialsFileMutex.RLock()
+	defer fake.withCredentialsFileMutex.RUnlock()
+	return len(fake.withCredentialsFileArgsForCall)
+}
+
+func (fake *FakeClient) WithCredentialsFileCalls(stub func(string)) {
+	fake.withCredentialsFileMutex.Lock()
+	defer fake.withCredentialsFileMutex.Unlock()
+	fake.WithCredentialsFileStub = stub
+}
+
+func (fake *FakeClient) WithCredentialsFileArgsForCall(i int) string {
+	fake.withCredentialsFileMutex.RLock()
+	defer fake.withCredentialsFileMutex.RUnlock()
+	argsForCall := fake.withCredentialsFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithCredentialsType(arg1 string) {
+	fake.withCredentialsTypeMutex.Lock()
+	fake.withCredentialsTypeArgsForCall = append(fake.withCredentialsTypeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithCredentialsTypeStub
+	fake.recordInvocation("WithCredentialsType", []interface{}{arg1})
+	fake.withCredentialsTypeMutex.Unlock()
+	if stub != nil {
+		fake.WithCredentialsTypeStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithCredentialsTypeCallCount() int {
+	fake.withCredentialsTypeMutex.RLock()
+	defer fake.withCredentialsTypeMutex.RUnlock()
+	return len(fake.withCredentialsTypeArgsForCall)
+}
+
+func (fake *FakeClient) WithCredentialsTypeCalls(stub func(string)) {
+	fake.withCredentialsTypeMutex.Lock()
+	defer fake.withCredentialsTypeMutex.Unlock()
+	fake.WithCredentialsTypeStub = stub
+}
+
+func (fake *FakeClient) WithCredentialsTypeArgsForCall(i int) string {
+	fake.withCredentialsTypeMutex.RLock()
+	defer fake.withCredentialsTypeMutex.RUnlock()
+	argsForCall := fake.withCredentialsTypeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithDelegates(arg1 []string) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.withDelegatesMutex.Lock()
+	fake.withDelegatesArgsForCall = append(fake.withDelegatesArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.WithDelegatesStub
+	fake.recordInvocation("WithDelegates", []interface{}{arg1Copy})
+	fake.withDelegatesMutex.Unlock()
+	if stub != nil {
+		fake.WithDelegatesStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithDelegatesCallCount() int {
+	fake.withDelegatesMutex.RLock()
+	defer fake.withDelegatesMutex.RUnlock()
+	return len(fake.withDelegatesArgsForCall)
+}
+
+func (fake *FakeClient) WithDelegatesCalls(stub func([]string)) {
+	fake.withDelegatesMutex.Lock()
+	defer fake.withDelegatesMutex.Unlock()
+	fake.WithDelegatesStub = stub
+}
+
+func (fake *FakeClient) WithDelegatesArgsForCall(i int) []string {
+	fake.withDelegatesMutex.RLock()
+	defer fake.withDelegatesMutex.RUnlock()
+	argsForCall := fake.withDelegatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithExpirySkew(arg1 time.Duration) {
+	fake.withExpirySkewMutex.Lock()
+	fake.withExpirySkewArgsForCall = append(fake.withExpirySkewArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.WithExpirySkewStub
+	fake.recordInvocation("WithExpirySkew", []interface{}{arg1})
+	fake.withExpirySkewMutex.Unlock()
+	if stub != nil {
+		fake.WithExpirySkewStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithExpirySkewCallCount() int {
+	fake.withExpirySkewMutex.RLock()
+	defer fake.withExpirySkewMutex.RUnlock()
+	return len(fake.withExpirySkewArgsForCall)
+}
+
+func (fake *FakeClient) WithExpirySkewCalls(stub func(time.Duration)) {
+	fake.withExpirySkewMutex.Lock()
+	defer fake.withExpirySkewMutex.Unlock()
+	fake.WithExpirySkewStub = stub
+}
+
+func (fake *FakeClient) WithExpirySkewArgsForCall(i int) time.Duration {
+	fake.withExpirySkewMutex.RLock()
+	defer fake.withExpirySkewMutex.RUnlock()
+	argsForCall := fake.withExpirySkewArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithIAMCredentialsURL(arg1 string) {
+	fake.withIAMCredentialsURLMutex.Lock()
+	fake.withIAMCredentialsURLArgsForCall = append(fake.withIAMCredentialsURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithIAMCredentialsURLStub
+	fake.recordInvocation("WithIAMCredentialsURL", []interface{}{arg1})
+	fake.withIAMCredentialsURLMutex.Unlock()
+	if stub != nil {
+		fake.WithIAMCredentialsURLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithIAMCredentialsURLCallCount() int {
+	fake.withIAMCredentialsURLMutex.RLock()
+	defer fake.withIAMCredentialsURLMutex.RUnlock()
+	return len(fake.withIAMCredentialsURLArgsForCall)
+}
+
+func (fake *FakeClient) WithIAMCredentialsURLCalls(stub func(string)) {
+	fake.withIAMCredentialsURLMutex.Lock()
+	defer fake.withIAMCredentialsURLMutex.Unlock()
+	fake.WithIAMCredentialsURLStub = stub
+}
+
+func (fake *FakeClient) WithIAMCredentialsURLArgsForCall(i int) string {
+	fake.withIAMCredentialsURLMutex.RLock()
+	defer fake.withIAMCredentialsURLMutex.RUnlock()
+	argsForCall := fake.withIAMCredentialsURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithImpersonateServiceAccount(arg1 string) {
+	fake.withImpersonateServiceAccountMutex.Lock()
+	fake.withImpersonateServiceAccountArgsForCall = append(fake.withImpersonateServiceAccountArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithImpersonateServiceAccountStub
+	fake.recordInvocation("WithImpersonateServiceAccount", []interface{}{arg1})
+	fake.withImpersonateServiceAccountMutex.Unlock()
+	if stub != nil {
+		fake.WithImpersonateServiceAccountStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithImpersonateServiceAccountCallCount() int {
+	fake.withImpersonateServiceAccountMutex.RLock()
+	defer fake.withImpersonateServiceAccountMutex.RUnlock()
+	return len(fake.withImpersonateServiceAccountArgsForCall)
+}
+
+func (fake *FakeClient) WithImpersonateServiceAccountCalls(stub func(string)) {
+	fake.withImpersonateServiceAccountMutex.Lock()
+	defer fake.withImpersonateServiceAccountMutex.Unlock()
+	fake.WithImpersonateServiceAccountStub = stub
+}
+
+func (fake *FakeClient) WithImpersonateServiceAccountArgsForCall(i int) string {
+	fake.withImpersonateServiceAccountMutex.RLock()
+	defer fake.withImpersonateServiceAccountMutex.RUnlock()
+	argsForCall := fake.withImpersonateServiceAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithMetadataURL(arg1 string) {
+	fake.withMetadataURLMutex.Lock()
+	fake.withMetadataURLArgsForCall = append(fake.withMetadataURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithMetadataURLStub
+	fake.recordInvocation("WithMetadataURL", []interface{}{arg1})
+	fake.withMetadataURLMutex.Unlock()
+	if stub != nil {
+		fake.WithMetadataURLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithMetadataURLCallCount() int {
+	fake.withMetadataURLMutex.RLock()
+	defer fake.withMetadataURLMutex.RUnlock()
+	return len(fake.withMetadataURLArgsForCall)
+}
+
+func (fake *FakeClient) WithMetadataURLCalls(stub func(string)) {
+	fake.withMetadataURLMutex.Lock()
+	defer fake.withMetadataURLMutex.Unlock()
+	fake.WithMetadataURLStub = stub
+}
+
+func (fake *FakeClient) WithMetadataURLArgsForCall(i int) string {
+	fake.withMetadataURLMutex.RLock()
+	defer fake.withMetadataURLMutex.RUnlock()
+	argsForCall := fake.withMetadataURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithSTSURL(arg1 string) {
+	fake.withSTSURLMutex.Lock()
+	fake.withSTSURLArgsForCall = append(fake.withSTSURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithSTSURLStub
+	fake.recordInvocation("WithSTSURL", []interface{}{arg1})
+	fake.withSTSURLMutex.Unlock()
+	if stub != nil {
+		fake.WithSTSURLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithSTSURLCallCount() int {
+	fake.withSTSURLMutex.RLock()
+	defer fake.withSTSURLMutex.RUnlock()
+	return len(fake.withSTSURLArgsForCall)
+}
+
+func (fake *FakeClient) WithSTSURLCalls(stub func(string)) {
+	fake.withSTSURLMutex.Lock()
+	defer fake.withSTSURLMutex.Unlock()
+	fake.WithSTSURLStub = stub
+}
+
+func (fake *FakeClient) WithSTSURLArgsForCall(i int) string {
+	fake.withSTSURLMutex.RLock()
+	defer fake.withSTSURLMutex.RUnlock()
+	argsForCall := fake.withSTSURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithScopes(arg1 []string) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.withScopesMutex.Lock()
+	fake.withScopesArgsForCall = append(fake.withScopesArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.WithScopesStub
+	fake.recordInvocation("WithScopes", []interface{}{arg1Copy})
+	fake.withScopesMutex.Unlock()
+	if stub != nil {
+		fake.WithScopesStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithScopesCallCount() int {
+	fake.withScopesMutex.RLock()
+	defer fake.withScopesMutex.RUnlock()
+	return len(fake.withScopesArgsForCall)
+}
+
+func (fake *FakeClient) WithScopesCalls(stub func([]string)) {
+	fake.withScopesMutex.Lock()
+	defer fake.withScopesMutex.Unlock()
+	fake.WithScopesStub = stub
+}
+
+func (fake *FakeClient) WithScopesArgsForCall(i int) []string {
+	fake.withScopesMutex.RLock()
+	defer fake.withScopesMutex.RUnlock()
+	argsForCall := fake.withScopesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.newDownscopedTokenMutex.RLock()
+	defer fake.newDownscopedTokenMutex.RUnlock()
+	fake.newIDTokenMutex.RLock()
+	defer fake.newIDTokenMutex.RUnlock()
+	fake.newImpersonatedIDTokenMutex.RLock()
+	defer fake.newImpersonatedIDTokenMutex.RUnlock()
+	fake.newImpersonatedTokenMutex.RLock()
+	defer fake.newImpersonatedTokenMutex.RUnlock()
+	fake.newScopedTokenMutex.RLock()
+	defer fake.newScopedTokenMutex.RUnlock()
 	fake.newTokenMutex.RLock()
 	defer fake.newTokenMutex.RUnlock()
+	fake.withCredentialsFileMutex.RLock()
+	defer fake.withCredentialsFileMutex.RUnlock()
+	fake.withCredentialsTypeMutex.RLock()
+	defer fake.withCredentialsTypeMutex.RUnlock()
+	fake.withDelegatesMutex.RLock()
+	defer fake.withDelegatesMutex.RUnlock()
+	fake.withExpirySkewMutex.RLock()
+	defer fake.withExpirySkewMutex.RUnlock()
+	fake.withIAMCredentialsURLMutex.RLock()
+	defer fake.withIAMCredentialsURLMutex.RUnlock()
+	fake.withImpersonateServiceAccountMutex.RLock()
+	defer fake.withImpersonateServiceAccountMutex.RUnlock()
+	fake.withMetadataURLMutex.RLock()
+	defer fake.withMetadataURLMutex.RUnlock()
+	fake.withSTSURLMutex.RLock()
+	defer fake.withSTSURLMutex.RUnlock()
+	fake.withScopesMutex.RLock()
+	defer fake.withScopesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,253 @@
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/provider"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jws"
+)
+
+const (
+	// DefaultMetadataURL is the base URL of the GCE metadata server.
+	DefaultMetadataURL = "http://metadata.google.internal"
+)
+
+var (
+	errIDTokenFormat         = "error getting id token: %s"
+	errIDTokenExternalFormat = "%w: id tokens require a service account key or a service account to impersonate, %s is an external account that does not impersonate one"
+)
+
+// impersonationURLRegexp matches the service account of the
+// service_account_impersonation_url of an external account.
+var impersonationURLRegexp = regexp.MustCompile(`/serviceAccounts/([^/:]+):generateAccessToken$`)
+
+// IDToken is a Google-signed OpenID Connect ID token.
+type IDToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type generateIDTokenRequest struct {
+	Delegates    []string `json:"delegates,omitempty"`
+	Audience     string   `json:"audience"`
+	IncludeEmail bool     `json:"includeEmail"`
+}
+
+type generateIDTokenResponse struct {
+	Token string `json:"token"`
+}
+
+// NewIDToken returns an ID token for audience. The token is signed for the
+// configured service account to impersonate, the service account of the
+// credentials file or the one an external account impersonates, or else
+// the service account of the metadata server.
+func (c *client) NewIDToken(ctx context.Context, audience string) (IDToken, error) {
+	if c.impersonateServiceAccount != "" {
+		return c.NewImpersonatedIDToken(ctx, Impersonation{
+			TargetPrincipal: c.impersonateServiceAccount,
+			Delegates:       c.delegates,
+		}, audience)
+	}
+
+	if c.credentialsFile != "" {
+		return c.newIDTokenFromCredentialsFile(ctx, audience)
+	}
+
+	return c.newIDTokenFromMetadata(ctx, audience)
+}
+
+// NewImpersonatedIDToken returns an ID token for audience signed for a
+// service account using the IAM Service Account Credentials API.
+func (c *client) NewImpersonatedIDToken(ctx context.Context, i Impersonation, audience string) (IDToken, error) {
+	c.mux.Lock()
+	base, err := c.base()
+	c.mux.Unlock()
+
+	if err != nil {
+		return IDToken{}, err
+	}
+
+	return c.generateIDToken(ctx, base, i, audience)
+}
+
+// generateIDToken calls the IAM Service Account Credentials API with the
+// tokens of ts to sign an ID token for the service account of i.
+func (c *client) generateIDToken(ctx context.Context, ts oauth2.TokenSource, i Impersonation, audience string) (IDToken, error) {
+	delegates := make([]string, 0, len(i.Delegates))
+	for _, delegate := range i.Delegates {
+		delegates = append(delegates, serviceAccountName(delegate))
+	}
+
+	b, err := json.Marshal(generateIDTokenRequest{
+		Delegates:    delegates,
+		Audience:     audience,
+		IncludeEmail: true,
+	})
+	if err != nil {
+		return IDToken{}, err
+	}
+
+	u := fmt.Sprintf("%s/v1/%s:generateIdToken", strings.TrimSuffix(c.iamCredentialsURL, "/"), serviceAccountName(i.TargetPrincipal))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewBuffer(b))
+	if err != nil {
+		return IDToken{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	b, err = do(oauth2.NewClient(ctx, ts), req)
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errImpersonateFormat, i.TargetPrincipal, err.Error())
+	}
+
+	gitr := generateIDTokenResponse{}
+
+	err = json.Unmarshal(b, &gitr)
+	if err != nil {
+		return IDToken{}, err
+	}
+
+	return newIDToken(gitr.Token)
+}
+
+func (c *client) newIDTokenFromCredentialsFile(ctx context.Context, audience string) (IDToken, error) {
+	c.mux.Lock()
+	err := c.reloadCredentials()
+	credentials := c.credentials
+	c.mux.Unlock()
+
+	if err != nil {
+		return IDToken{}, err
+	}
+
+	f := struct {
+		Type                           string `json:"type"`
+		ServiceAccountImpersonationURL string `json:"service_account_impersonation_url"`
+	}{}
+
+	err = json.Unmarshal(credentials, &f)
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errIDTokenFormat, err.Error())
+	}
+
+	if f.Type == CredentialsTypeExternalAccount {
+		return c.newIDTokenFromExternalAccount(ctx, credentials, f.ServiceAccountImpersonationURL, audience)
+	}
+
+	// Only service account keys can sign ID tokens.
+	cfg, err := google.JWTConfigFromJSON(credentials)
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errIDTokenFormat, err.Error())
+	}
+
+	cfg.PrivateClaims = map[string]interface{}{"target_audience": audience}
+	cfg.UseIDToken = true
+
+	token, err := cfg.TokenSource(ctx).Token()
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errIDTokenFormat, err.Error())
+	}
+
+	return IDToken{
+		Token:     token.AccessToken,
+		ExpiresAt: token.Expiry.In(time.UTC),
+	}, nil
+}
+
+// newIDTokenFromExternalAccount signs an ID token for the service account
+// an external account impersonates. The federated token is used as is, as
+// impersonating the service account already requires it to be allowed to
+// create tokens for it.
+func (c *client) newIDTokenFromExternalAccount(ctx context.Context, credentials []byte, impersonationURL, audience string) (IDToken, error) {
+	m := impersonationURLRegexp.FindStringSubmatch(impersonationURL)
+	if m == nil {
+		return IDToken{}, fmt.Errorf(errIDTokenExternalFormat, provider.ErrInvalidParams, c.credentialsFile)
+	}
+
+	external := map[string]interface{}{}
+
+	err := json.Unmarshal(credentials, &external)
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errIDTokenFormat, err.Error())
+	}
+
+	delete(external, "service_account_impersonation_url")
+
+	b, err := json.Marshal(external)
+	if err != nil {
+		return IDToken{}, err
+	}
+
+	federated, err := c.newTokenSource(ctx, b, defaultScopes)
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errIDTokenFormat, err.Error())
+	}
+
+	return c.generateIDToken(ctx, federated, Impersonation{TargetPrincipal: m[1]}, audience)
+}
+
+func (c *client) newIDTokenFromMetadata(ctx context.Context, audience string) (IDToken, error) {
+	u := fmt.Sprintf("%s/computeMetadata/v1/instance/service-accounts/default/identity?audience=%s&format=full",
+		strings.TrimSuffix(c.metadataURL, "/"), url.QueryEscape(audience))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return IDToken{}, err
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	b, err := do(http.DefaultClient, req)
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errIDTokenFormat, err.Error())
+	}
+
+	return newIDToken(strings.TrimSpace(string(b)))
+}
+
+// newIDToken reads the expiry of an ID token. The token comes straight
+// from google, so its signature is not verified.
+func newIDToken(token string) (IDToken, error) {
+	claims, err := jws.Decode(token)
+	if err != nil {
+		return IDToken{}, fmt.Errorf(errIDTokenFormat, err.Error())
+	}
+
+	return IDToken{
+		Token:     token,
+		ExpiresAt: time.Unix(claims.Exp, 0).In(time.UTC),
+	}, nil
+}
+
+// do sends req and returns the response body, or an error if the status
+// is not 200.
+func do(hc *http.Client, req *http.Request) ([]byte, error) {
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", res.Status, string(b))
+	}
+
+	return b, nil
+}
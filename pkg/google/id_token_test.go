@@ -0,0 +1,246 @@
+package google_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/google"
+	"github.com/homedepot/arcade/pkg/provider"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("IDToken", func() {
+	var (
+		server  *ghttp.Server
+		dir     string
+		client  Client
+		idToken IDToken
+		err     error
+		exp     time.Time
+		jwt     string
+		ctx     context.Context
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		dir, _ = ioutil.TempDir("", "arcade-google")
+		exp = time.Now().Add(time.Hour).Truncate(time.Second).In(time.UTC)
+		jwt = newJWT(fmt.Sprintf(`{"aud":"https://service.example.com","exp":%d}`, exp.Unix()))
+		ctx = context.Background()
+		client = NewClient()
+		client.WithMetadataURL(server.URL())
+		client.WithIAMCredentialsURL(server.URL())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("#NewIDToken", func() {
+		JustBeforeEach(func() {
+			idToken, err = client.NewIDToken(ctx, "https://service.example.com")
+		})
+
+		When("the metadata server returns an error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusNotFound, "not found"),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting id token: 404 Not Found: not found"))
+			})
+		})
+
+		When("the metadata server returns a malformed token", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, "not-a-jwt"),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("it gets the token from the metadata server", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, "/computeMetadata/v1/instance/service-accounts/default/identity",
+							"audience=https%3A%2F%2Fservice.example.com&format=full"),
+						ghttp.VerifyHeaderKV("Metadata-Flavor", "Google"),
+						ghttp.RespondWith(http.StatusOK, jwt),
+					),
+				)
+			})
+
+			It("returns the token with its expiry", func() {
+				Expect(err).To(BeNil())
+				Expect(idToken.Token).To(Equal(jwt))
+				Expect(idToken.ExpiresAt).To(Equal(exp))
+			})
+		})
+
+		When("it gets the token with a credentials file", func() {
+			BeforeEach(func() {
+				client.WithCredentialsFile(writeServiceAccountKey(dir, server.URL()+"/token"))
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/token"),
+						ghttp.RespondWith(http.StatusOK, `{"id_token":"`+jwt+`"}`,
+							http.Header{"Content-Type": []string{"application/json"}}),
+					),
+				)
+			})
+
+			It("returns the token with its expiry", func() {
+				Expect(err).To(BeNil())
+				Expect(idToken.Token).To(Equal(jwt))
+				Expect(idToken.ExpiresAt).To(Equal(exp))
+			})
+		})
+
+		When("the credentials file is an external account that impersonates a service account", func() {
+			BeforeEach(func() {
+				// The STS URL must be a google one, so requests are sent to
+				// the test server by the HTTP client of the context.
+				ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: &serverTransport{url: server.URL()}})
+				client.WithCredentialsFile(writeExternalAccount(dir,
+					"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/deploy@arcade-test.iam.gserviceaccount.com:generateAccessToken"))
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/token"),
+						ghttp.RespondWith(http.StatusOK, `{"access_token":"federated-token","issued_token_type":"urn:ietf:params:oauth:token-type:access_token","token_type":"Bearer","expires_in":3600}`,
+							http.Header{"Content-Type": []string{"application/json"}}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/projects/-/serviceAccounts/deploy@arcade-test.iam.gserviceaccount.com:generateIdToken"),
+						ghttp.VerifyHeaderKV("Authorization", "Bearer federated-token"),
+						ghttp.VerifyJSON(`{"audience":"https://service.example.com","includeEmail":true}`),
+						ghttp.RespondWith(http.StatusOK, `{"token":"`+jwt+`"}`),
+					),
+				)
+			})
+
+			It("returns the token of the impersonated service account", func() {
+				Expect(err).To(BeNil())
+				Expect(idToken.Token).To(Equal(jwt))
+				Expect(idToken.ExpiresAt).To(Equal(exp))
+			})
+		})
+
+		When("the credentials file is an external account that does not impersonate a service account", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = writeExternalAccount(dir, "")
+				client.WithCredentialsFile(path)
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: id tokens require a service account key or a service account to impersonate, " +
+					path + " is an external account that does not impersonate one"))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		When("a service account to impersonate is configured", func() {
+			BeforeEach(func() {
+				client.WithCredentialsFile(writeServiceAccountKey(dir, server.URL()+"/token"))
+				client.WithImpersonateServiceAccount("deploy@arcade-test.iam.gserviceaccount.com")
+				server.RouteToHandler(http.MethodPost, "/token",
+					ghttp.RespondWith(http.StatusOK, `{"access_token":"base-token","token_type":"Bearer","expires_in":3600}`,
+						http.Header{"Content-Type": []string{"application/json"}}),
+				)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/projects/-/serviceAccounts/deploy@arcade-test.iam.gserviceaccount.com:generateIdToken"),
+						ghttp.VerifyHeaderKV("Authorization", "Bearer base-token"),
+						ghttp.VerifyJSON(`{"audience":"https://service.example.com","includeEmail":true}`),
+						ghttp.RespondWith(http.StatusOK, `{"token":"`+jwt+`"}`),
+					),
+				)
+			})
+
+			It("returns the token of the service account", func() {
+				Expect(err).To(BeNil())
+				Expect(idToken.Token).To(Equal(jwt))
+				Expect(idToken.ExpiresAt).To(Equal(exp))
+			})
+		})
+	})
+})
+
+// writeExternalAccount writes a Workload Identity Federation credential
+// configuration that exchanges a subject token file with google STS.
+func writeExternalAccount(dir, impersonationURL string) string {
+	subjectTokenFile := filepath.Join(dir, "subject-token")
+	Expect(ioutil.WriteFile(subjectTokenFile, []byte("subject-token"), 0600)).To(Succeed())
+
+	external := map[string]interface{}{
+		"type":               "external_account",
+		"audience":           "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/arcade/providers/test",
+		"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+		"token_url":          "https://sts.googleapis.com/v1/token",
+		"credential_source": map[string]string{
+			"file": subjectTokenFile,
+		},
+	}
+	if impersonationURL != "" {
+		external["service_account_impersonation_url"] = impersonationURL
+	}
+
+	b, err := json.Marshal(external)
+	Expect(err).To(BeNil())
+
+	path := filepath.Join(dir, "external-account.json")
+	Expect(ioutil.WriteFile(path, b, 0600)).To(Succeed())
+
+	return path
+}
+
+// serverTransport sends every request to the test server at url.
+type serverTransport struct {
+	url string
+}
+
+func (t *serverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u, err := url.Parse(t.url)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.Scheme = u.Scheme
+	req.URL.Host = u.Host
+	req.Host = u.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newJWT(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	return header + "." + payload + ".ZmFrZQ"
+}
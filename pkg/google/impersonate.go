@@ -0,0 +1,102 @@
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// DefaultIAMCredentialsURL is the base URL of the IAM Service Account
+	// Credentials API.
+	DefaultIAMCredentialsURL = "https://iamcredentials.googleapis.com"
+)
+
+var (
+	errImpersonateFormat = "error impersonating %s: %s"
+)
+
+// Impersonation describes a service account to get a token for using the
+// IAM Service Account Credentials API.
+type Impersonation struct {
+	// TargetPrincipal is the email of the service account.
+	TargetPrincipal string
+	// Delegates is the chain of service accounts that grant the
+	// Service Account Token Creator role to the next in the chain, ending
+	// with the target principal.
+	Delegates []string
+	Scopes    []string
+}
+
+type generateAccessTokenRequest struct {
+	Delegates []string `json:"delegates,omitempty"`
+	Scope     []string `json:"scope"`
+}
+
+type generateAccessTokenResponse struct {
+	AccessToken string    `json:"accessToken"`
+	ExpireTime  time.Time `json:"expireTime"`
+}
+
+// impersonatedTokenSource creates tokens for a service account with the
+// tokens of a base token source.
+type impersonatedTokenSource struct {
+	ctx           context.Context
+	base          oauth2.TokenSource
+	url           string
+	impersonation Impersonation
+}
+
+func (ts *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	i := ts.impersonation
+
+	delegates := make([]string, 0, len(i.Delegates))
+	for _, delegate := range i.Delegates {
+		delegates = append(delegates, serviceAccountName(delegate))
+	}
+
+	b, err := json.Marshal(generateAccessTokenRequest{
+		Delegates: delegates,
+		Scope:     i.Scopes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/v1/%s:generateAccessToken", strings.TrimSuffix(ts.url, "/"), serviceAccountName(i.TargetPrincipal))
+
+	req, err := http.NewRequestWithContext(ts.ctx, http.MethodPost, u, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	b, err = do(oauth2.NewClient(ts.ctx, ts.base), req)
+	if err != nil {
+		return nil, fmt.Errorf(errImpersonateFormat, i.TargetPrincipal, err.Error())
+	}
+
+	gatr := generateAccessTokenResponse{}
+
+	err = json.Unmarshal(b, &gatr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: gatr.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      gatr.ExpireTime,
+	}, nil
+}
+
+func serviceAccountName(email string) string {
+	return "projects/-/serviceAccounts/" + email
+}
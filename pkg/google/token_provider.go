@@ -0,0 +1,259 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/provider"
+	"golang.org/x/oauth2"
+)
+
+const (
+	paramImpersonate = "impersonate"
+	paramDelegate    = "delegate"
+	paramType        = "type"
+	paramAudience    = "audience"
+	paramScope       = "scope"
+
+	typeAccessToken = "accesstoken"
+	typeIDToken     = "idtoken"
+)
+
+// TokenProviderOptions configure the token provider of a Client.
+type TokenProviderOptions struct {
+	// ExpirySkew is how long before they expire tokens are no longer
+	// cached. It should match the expiry skew of the client.
+	ExpirySkew time.Duration
+	// AllowedScopes are the scopes callers may request with the scope
+	// parameter. Requesting scopes is not allowed when it is empty.
+	AllowedScopes []string
+	// AllowedServiceAccounts are the service accounts callers may
+	// impersonate or name as delegates with the impersonate and delegate
+	// parameters. Impersonating is not allowed when it is empty.
+	AllowedServiceAccounts []string
+	// ImpersonateServiceAccount is the service account the client is
+	// configured to impersonate. Callers may not impersonate another one
+	// when it is set.
+	ImpersonateServiceAccount string
+	// AccessBoundaries maps the names of API keys to the Credential Access
+	// Boundary that downscopes the access tokens of their callers.
+	AccessBoundaries map[string]AccessBoundary
+}
+
+// NewTokenProvider returns a provider.TokenProvider that creates access
+// tokens with c.
+func NewTokenProvider(c Client, opts TokenProviderOptions) provider.TokenProvider {
+	return &tokenProvider{
+		c:    c,
+		opts: opts,
+	}
+}
+
+type tokenProvider struct {
+	c    Client
+	opts TokenProviderOptions
+}
+
+func (tp *tokenProvider) NewToken(ctx context.Context) (provider.Token, error) {
+	token, err := tp.c.NewToken(ctx)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	return tp.token(token), nil
+}
+
+// ExpirySkew returns the expiry skew of the client, so the cache replaces
+// tokens when the client refreshes them.
+func (tp *tokenProvider) ExpirySkew() time.Duration {
+	return tp.opts.ExpirySkew
+}
+
+// Params returns the query parameters used to impersonate a service
+// account, for example impersonate=sa@project.iam.gserviceaccount.com,
+// to request an ID token with type=idtoken&audience=... and to request
+// scopes. The delegate and scope parameters are repeatable. If access
+// boundaries are configured the name of the caller's API key is read too.
+func (tp *tokenProvider) Params() []string {
+	params := []string{paramImpersonate, paramDelegate, paramType, paramAudience, paramScope}
+
+	if len(tp.opts.AccessBoundaries) > 0 {
+		params = append(params, provider.ParamAPIKey)
+	}
+
+	return params
+}
+
+// NormalizeParams sorts the requested scopes so tokens are cached per set
+// of scopes, and drops API keys without an access boundary so their
+// callers share tokens.
+func (tp *tokenProvider) NormalizeParams(params url.Values) url.Values {
+	if scopes, ok := params[paramScope]; ok {
+		sorted := append([]string(nil), scopes...)
+		sort.Strings(sorted)
+		params[paramScope] = sorted
+	}
+
+	if _, ok := tp.opts.AccessBoundaries[params.Get(provider.ParamAPIKey)]; !ok {
+		params.Del(provider.ParamAPIKey)
+	}
+
+	return params
+}
+
+func (tp *tokenProvider) NewTokenWithParams(ctx context.Context, params url.Values) (provider.Token, error) {
+	boundary, downscope := tp.opts.AccessBoundaries[params.Get(provider.ParamAPIKey)]
+
+	err := tp.checkImpersonation(params)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	switch params.Get(paramType) {
+	case "", typeAccessToken:
+	case typeIDToken:
+		if downscope {
+			return provider.Token{}, fmt.Errorf("%w: id tokens are not available with an access boundary", provider.ErrInvalidParams)
+		}
+
+		return tp.newIDToken(ctx, params)
+	default:
+		return provider.Token{}, fmt.Errorf("%w: unsupported type %s", provider.ErrInvalidParams, params.Get(paramType))
+	}
+
+	scopes := params[paramScope]
+	for _, scope := range scopes {
+		if !contains(tp.opts.AllowedScopes, scope) {
+			return provider.Token{}, fmt.Errorf("%w: scope not allowed: %s", provider.ErrInvalidParams, scope)
+		}
+	}
+
+	var token *oauth2.Token
+
+	targetPrincipal := params.Get(paramImpersonate)
+
+	switch {
+	case targetPrincipal != "":
+		token, err = tp.c.NewImpersonatedToken(ctx, Impersonation{
+			TargetPrincipal: targetPrincipal,
+			Delegates:       params[paramDelegate],
+			Scopes:          scopes,
+		})
+	case len(scopes) > 0:
+		token, err = tp.c.NewScopedToken(ctx, scopes)
+	default:
+		token, err = tp.c.NewToken(ctx)
+	}
+
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	if downscope {
+		token, err = tp.c.NewDownscopedToken(ctx, token, boundary)
+		if err != nil {
+			return provider.Token{}, err
+		}
+	}
+
+	t := tp.token(token)
+	t.Metadata = map[string]string{}
+
+	if targetPrincipal != "" {
+		t.Metadata["serviceAccount"] = targetPrincipal
+	}
+
+	if downscope {
+		t.Metadata["accessBoundary"] = params.Get(provider.ParamAPIKey)
+	}
+
+	return t, nil
+}
+
+// checkImpersonation returns an invalid params error if the requested
+// service account or delegates are not allowed.
+func (tp *tokenProvider) checkImpersonation(params url.Values) error {
+	targetPrincipal := params.Get(paramImpersonate)
+	delegates := params[paramDelegate]
+
+	if targetPrincipal == "" && len(delegates) == 0 {
+		return nil
+	}
+
+	if tp.opts.ImpersonateServiceAccount != "" {
+		return fmt.Errorf("%w: provider impersonates %s, impersonate and delegate are not allowed",
+			provider.ErrInvalidParams, tp.opts.ImpersonateServiceAccount)
+	}
+
+	if targetPrincipal == "" {
+		return fmt.Errorf("%w: delegate requires impersonate", provider.ErrInvalidParams)
+	}
+
+	for _, serviceAccount := range append([]string{targetPrincipal}, delegates...) {
+		if !contains(tp.opts.AllowedServiceAccounts, serviceAccount) {
+			return fmt.Errorf("%w: service account not allowed: %s", provider.ErrInvalidParams, serviceAccount)
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (tp *tokenProvider) newIDToken(ctx context.Context, params url.Values) (provider.Token, error) {
+	audience := params.Get(paramAudience)
+	if audience == "" {
+		return provider.Token{}, fmt.Errorf("%w: audience not set", provider.ErrInvalidParams)
+	}
+
+	var (
+		idToken IDToken
+		err     error
+	)
+
+	targetPrincipal := params.Get(paramImpersonate)
+	if targetPrincipal == "" {
+		idToken, err = tp.c.NewIDToken(ctx, audience)
+	} else {
+		idToken, err = tp.c.NewImpersonatedIDToken(ctx, Impersonation{
+			TargetPrincipal: targetPrincipal,
+			Delegates:       params[paramDelegate],
+		}, audience)
+	}
+
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	t := provider.Token{
+		Token:     idToken.Token,
+		ExpiresAt: idToken.ExpiresAt,
+		Metadata: map[string]string{
+			"audience": audience,
+		},
+	}
+
+	if targetPrincipal != "" {
+		t.Metadata["serviceAccount"] = targetPrincipal
+	}
+
+	return t, nil
+}
+
+func (tp *tokenProvider) token(token *oauth2.Token) provider.Token {
+	return provider.Token{
+		Token:     token.AccessToken,
+		ExpiresAt: token.Expiry.In(time.UTC),
+	}
+}
@@ -0,0 +1,317 @@
+package google_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/google"
+	"github.com/homedepot/arcade/pkg/google/googlefakes"
+	"github.com/homedepot/arcade/pkg/provider"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("TokenProvider", func() {
+	var (
+		fakeClient *googlefakes.FakeClient
+		expiry     time.Time
+		t          provider.Token
+		err        error
+	)
+
+	BeforeEach(func() {
+		expiry = time.Now().Add(time.Hour).In(time.UTC)
+		fakeClient = &googlefakes.FakeClient{}
+		fakeClient.NewTokenReturns(&oauth2.Token{AccessToken: "fake-google-token", Expiry: expiry}, nil)
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			t, err = NewTokenProvider(fakeClient, TokenProviderOptions{ExpirySkew: 5 * time.Minute}).NewToken(context.Background())
+		})
+
+		When("the client fails", func() {
+			BeforeEach(func() {
+				fakeClient.NewTokenReturns(nil, errors.New("error getting token"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns the token with its expiry", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-google-token"))
+				Expect(t.ExpiresAt).To(Equal(expiry))
+			})
+		})
+	})
+
+	Describe("#NewTokenWithParams", func() {
+		var (
+			tp     provider.ParamsTokenProvider
+			params url.Values
+		)
+
+		BeforeEach(func() {
+			tp = NewTokenProvider(fakeClient, TokenProviderOptions{ExpirySkew: 5 * time.Minute}).(provider.ParamsTokenProvider)
+			params = url.Values{}
+			fakeClient.NewIDTokenReturns(IDToken{Token: "fake-id-token", ExpiresAt: expiry}, nil)
+		})
+
+		JustBeforeEach(func() {
+			t, err = tp.NewTokenWithParams(context.Background(), params)
+		})
+
+		When("the type is not supported", func() {
+			BeforeEach(func() {
+				params.Set("type", "refreshtoken")
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: unsupported type refreshtoken"))
+			})
+		})
+
+		When("a scope is not allowed", func() {
+			BeforeEach(func() {
+				params["scope"] = []string{"https://www.googleapis.com/auth/devstorage.read_only"}
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: scope not allowed: https://www.googleapis.com/auth/devstorage.read_only"))
+			})
+		})
+
+		When("allowed scopes are requested", func() {
+			BeforeEach(func() {
+				tp = NewTokenProvider(fakeClient, TokenProviderOptions{
+					AllowedScopes: []string{
+						"https://www.googleapis.com/auth/devstorage.read_only",
+						"https://www.googleapis.com/auth/userinfo.email",
+					},
+				}).(provider.ParamsTokenProvider)
+				fakeClient.NewScopedTokenReturns(&oauth2.Token{AccessToken: "scoped-token", Expiry: expiry}, nil)
+				params["scope"] = []string{"https://www.googleapis.com/auth/devstorage.read_only"}
+			})
+
+			It("returns a token with the scopes", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("scoped-token"))
+				_, scopes := fakeClient.NewScopedTokenArgsForCall(0)
+				Expect(scopes).To(Equal([]string{"https://www.googleapis.com/auth/devstorage.read_only"}))
+			})
+
+			When("a service account is impersonated", func() {
+				BeforeEach(func() {
+					tp = NewTokenProvider(fakeClient, TokenProviderOptions{
+						AllowedScopes:          []string{"https://www.googleapis.com/auth/devstorage.read_only"},
+						AllowedServiceAccounts: []string{"deploy@arcade-test.iam.gserviceaccount.com"},
+					}).(provider.ParamsTokenProvider)
+					fakeClient.NewImpersonatedTokenReturns(&oauth2.Token{AccessToken: "impersonated-token", Expiry: expiry}, nil)
+					params.Set("impersonate", "deploy@arcade-test.iam.gserviceaccount.com")
+				})
+
+				It("requests the scopes for the service account", func() {
+					Expect(err).To(BeNil())
+					Expect(t.Token).To(Equal("impersonated-token"))
+					_, i := fakeClient.NewImpersonatedTokenArgsForCall(0)
+					Expect(i.Scopes).To(Equal([]string{"https://www.googleapis.com/auth/devstorage.read_only"}))
+				})
+			})
+		})
+
+		When("an id token is requested without an audience", func() {
+			BeforeEach(func() {
+				params.Set("type", "idtoken")
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: audience not set"))
+			})
+		})
+
+		When("an id token is requested", func() {
+			BeforeEach(func() {
+				params.Set("type", "idtoken")
+				params.Set("audience", "https://service.example.com")
+			})
+
+			It("returns the id token", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-id-token"))
+				Expect(t.ExpiresAt).To(Equal(expiry))
+				Expect(t.Metadata).To(HaveKeyWithValue("audience", "https://service.example.com"))
+				Expect(fakeClient.NewIDTokenCallCount()).To(Equal(1))
+			})
+		})
+
+		When("impersonating is not allowed", func() {
+			BeforeEach(func() {
+				params.Set("impersonate", "deploy@arcade-test.iam.gserviceaccount.com")
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: service account not allowed: deploy@arcade-test.iam.gserviceaccount.com"))
+				Expect(fakeClient.NewImpersonatedTokenCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a delegate is not allowed", func() {
+			BeforeEach(func() {
+				tp = NewTokenProvider(fakeClient, TokenProviderOptions{
+					AllowedServiceAccounts: []string{"deploy@arcade-test.iam.gserviceaccount.com"},
+				}).(provider.ParamsTokenProvider)
+				params.Set("impersonate", "deploy@arcade-test.iam.gserviceaccount.com")
+				params.Set("delegate", "owner@arcade-test.iam.gserviceaccount.com")
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: service account not allowed: owner@arcade-test.iam.gserviceaccount.com"))
+			})
+		})
+
+		When("a delegate is set without impersonate", func() {
+			BeforeEach(func() {
+				params.Set("delegate", "deploy@arcade-test.iam.gserviceaccount.com")
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: delegate requires impersonate"))
+			})
+		})
+
+		When("the provider impersonates a configured service account", func() {
+			BeforeEach(func() {
+				tp = NewTokenProvider(fakeClient, TokenProviderOptions{
+					AllowedServiceAccounts:    []string{"deploy@arcade-test.iam.gserviceaccount.com"},
+					ImpersonateServiceAccount: "ci@arcade-test.iam.gserviceaccount.com",
+				}).(provider.ParamsTokenProvider)
+				params.Set("impersonate", "deploy@arcade-test.iam.gserviceaccount.com")
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				Expect(err.Error()).To(Equal("invalid parameters: provider impersonates ci@arcade-test.iam.gserviceaccount.com, impersonate and delegate are not allowed"))
+				Expect(fakeClient.NewImpersonatedTokenCallCount()).To(Equal(0))
+			})
+		})
+
+		When("an id token of an impersonated service account is requested", func() {
+			BeforeEach(func() {
+				tp = NewTokenProvider(fakeClient, TokenProviderOptions{
+					AllowedServiceAccounts: []string{"deploy@arcade-test.iam.gserviceaccount.com"},
+				}).(provider.ParamsTokenProvider)
+				fakeClient.NewImpersonatedIDTokenReturns(IDToken{Token: "impersonated-id-token", ExpiresAt: expiry}, nil)
+				params.Set("type", "idtoken")
+				params.Set("audience", "https://service.example.com")
+				params.Set("impersonate", "deploy@arcade-test.iam.gserviceaccount.com")
+			})
+
+			It("returns the id token of the service account", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("impersonated-id-token"))
+				_, i, audience := fakeClient.NewImpersonatedIDTokenArgsForCall(0)
+				Expect(i.TargetPrincipal).To(Equal("deploy@arcade-test.iam.gserviceaccount.com"))
+				Expect(audience).To(Equal("https://service.example.com"))
+			})
+		})
+	})
+
+	Describe("#ExpirySkew", func() {
+		It("returns the expiry skew of the client", func() {
+			tp := NewTokenProvider(fakeClient, TokenProviderOptions{ExpirySkew: 5 * time.Minute}).(provider.ExpirySkewer)
+			Expect(tp.ExpirySkew()).To(Equal(5 * time.Minute))
+		})
+	})
+
+	Describe("#NormalizeParams", func() {
+		It("sorts the scopes", func() {
+			tp := NewTokenProvider(fakeClient, TokenProviderOptions{}).(provider.ParamsNormalizer)
+			params := tp.NormalizeParams(url.Values{"scope": []string{"b", "a"}, "delegate": []string{"d2", "d1"}})
+			Expect(params["scope"]).To(Equal([]string{"a", "b"}))
+			Expect(params["delegate"]).To(Equal([]string{"d2", "d1"}))
+		})
+	})
+
+	Describe("access boundaries", func() {
+		var (
+			tp     provider.ParamsTokenProvider
+			params url.Values
+		)
+
+		BeforeEach(func() {
+			tp = NewTokenProvider(fakeClient, TokenProviderOptions{
+				AccessBoundaries: map[string]AccessBoundary{
+					"artifacts": {
+						AccessBoundaryRules: []AccessBoundaryRule{
+							{AvailableResource: "//storage.googleapis.com/projects/_/buckets/artifacts"},
+						},
+					},
+				},
+			}).(provider.ParamsTokenProvider)
+			params = url.Values{}
+			fakeClient.NewDownscopedTokenReturns(&oauth2.Token{AccessToken: "downscoped-token", Expiry: expiry}, nil)
+		})
+
+		JustBeforeEach(func() {
+			t, err = tp.NewTokenWithParams(context.Background(), params)
+		})
+
+		It("reads the API key name", func() {
+			Expect(tp.Params()).To(ContainElement(provider.ParamAPIKey))
+		})
+
+		It("drops API keys without an access boundary when normalizing", func() {
+			normalizer := tp.(provider.ParamsNormalizer)
+			Expect(normalizer.NormalizeParams(url.Values{"apiKey": []string{"default"}})).ToNot(HaveKey("apiKey"))
+			Expect(normalizer.NormalizeParams(url.Values{"apiKey": []string{"artifacts"}})).To(HaveKey("apiKey"))
+		})
+
+		When("the API key has an access boundary", func() {
+			BeforeEach(func() {
+				params.Set("apiKey", "artifacts")
+			})
+
+			It("downscopes the token", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("downscoped-token"))
+				Expect(t.Metadata).To(HaveKeyWithValue("accessBoundary", "artifacts"))
+				_, source, boundary := fakeClient.NewDownscopedTokenArgsForCall(0)
+				Expect(source.AccessToken).To(Equal("fake-google-token"))
+				Expect(boundary.AccessBoundaryRules[0].AvailableResource).To(Equal("//storage.googleapis.com/projects/_/buckets/artifacts"))
+			})
+
+			When("an id token is requested", func() {
+				BeforeEach(func() {
+					params.Set("type", "idtoken")
+					params.Set("audience", "https://service.example.com")
+				})
+
+				It("returns an invalid params error", func() {
+					Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+				})
+			})
+		})
+
+		When("the API key has no access boundary", func() {
+			It("does not downscope the token", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-google-token"))
+				Expect(fakeClient.NewDownscopedTokenCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
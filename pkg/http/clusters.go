@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+// ClusterResponse is a cluster of a provider instance as returned by
+// GetClusters.
+type ClusterResponse struct {
+	Name   string `json:"name"`
+	ID     string `json:"id,omitempty"`
+	Server string `json:"server"`
+	// CertificateAuthorityData is the base64 encoded PEM CA of the server.
+	CertificateAuthorityData string            `json:"certificateAuthorityData,omitempty"`
+	InsecureSkipTLSVerify    bool              `json:"insecureSkipTLSVerify,omitempty"`
+	Metadata                 map[string]string `json:"metadata,omitempty"`
+}
+
+// GetClusters returns the clusters configured for the provider instance
+// named in the path, followed by the clusters its token provider
+// discovers.
+func GetClusters(c *gin.Context) {
+	instance := instanceNamed(c, c.Param("provider"))
+	if instance == nil {
+		return
+	}
+
+	clusters, err := clustersOf(c, instance)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	res := make([]ClusterResponse, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		cr := ClusterResponse{
+			Name:                  cluster.Name,
+			ID:                    cluster.ID,
+			Server:                cluster.Server,
+			InsecureSkipTLSVerify: cluster.InsecureSkipTLSVerify,
+			Metadata:              cluster.Metadata,
+		}
+
+		if len(cluster.CertificateAuthorityData) > 0 {
+			cr.CertificateAuthorityData = base64.StdEncoding.EncodeToString(cluster.CertificateAuthorityData)
+		}
+
+		res = append(res, cr)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"clusters": res})
+}
+
+// clustersOf returns the clusters configured for instance followed by the
+// clusters its token provider discovers.
+func clustersOf(c *gin.Context, instance *provider.Instance) ([]provider.Cluster, error) {
+	clusters := append([]provider.Cluster(nil), instance.Clusters...)
+
+	if discoverer, ok := instance.TokenProvider.(provider.ClusterDiscoverer); ok {
+		discovered, err := discoverer.Clusters(c)
+		if err != nil {
+			return nil, fmt.Errorf("error discovering clusters: %s", err.Error())
+		}
+
+		clusters = append(clusters, discovered...)
+	}
+
+	return clusters, nil
+}
@@ -0,0 +1,111 @@
+package http_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	arcadehttp "github.com/homedepot/arcade/pkg/http"
+	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/provider"
+	"github.com/homedepot/arcade/pkg/provider/providerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type clustersResponse struct {
+	Clusters []arcadehttp.ClusterResponse `json:"clusters"`
+	Error    string                       `json:"error"`
+}
+
+var _ = Describe("Clusters", func() {
+	var (
+		discoverer *fakeDiscoverer
+		clusters   clustersResponse
+	)
+
+	BeforeEach(func() {
+		discoverer = &fakeDiscoverer{
+			FakeTokenProvider: &providerfakes.FakeTokenProvider{},
+			clusters: []provider.Cluster{
+				{
+					Name:     "prod-east",
+					ID:       "c-abc12",
+					Server:   "https://rancher.example.com/k8s/clusters/c-abc12",
+					Metadata: map[string]string{"state": "active"},
+				},
+			},
+		}
+
+		p := provider.NewRegistry()
+		_ = p.Register("clusters-rancher", provider.TypeRancher, discoverer)
+		_ = p.RegisterClusters("clusters-rancher", provider.Cluster{
+			Name:                     "lab",
+			Server:                   "https://lab.example.com",
+			CertificateAuthorityData: []byte(fakeCA),
+		})
+
+		r := gin.New()
+		r.Use(gin.Recovery())
+		r.Use(middleware.SetProviderRegistry(p))
+		r.GET("/providers/:provider/clusters", arcadehttp.GetClusters)
+
+		svr = httptest.NewServer(r)
+		uri = svr.URL + "/providers/clusters-rancher/clusters"
+		clusters = clustersResponse{}
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		res.Body.Close()
+	})
+
+	JustBeforeEach(func() {
+		req, _ = http.NewRequest(http.MethodGet, uri, nil)
+		res, err = http.DefaultClient.Do(req)
+		b, _ := ioutil.ReadAll(res.Body)
+		_ = json.Unmarshal(b, &clusters)
+	})
+
+	Describe("#GetClusters", func() {
+		When("the provider is not configured", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/providers/fake/clusters"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(clusters.Error).To(Equal("Unsupported token provider: fake"))
+			})
+		})
+
+		When("discovering clusters fails", func() {
+			BeforeEach(func() {
+				discoverer.err = errors.New("error listing clusters: 403 Forbidden")
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(clusters.Error).To(Equal("error discovering clusters: error listing clusters: 403 Forbidden"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns the configured and discovered clusters", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(clusters.Clusters).To(HaveLen(2))
+				Expect(clusters.Clusters[0].Name).To(Equal("lab"))
+				Expect(clusters.Clusters[0].CertificateAuthorityData).To(Equal(base64.StdEncoding.EncodeToString([]byte(fakeCA))))
+				Expect(clusters.Clusters[1].Name).To(Equal("prod-east"))
+				Expect(clusters.Clusters[1].ID).To(Equal("c-abc12"))
+				Expect(clusters.Clusters[1].Server).To(Equal("https://rancher.example.com/k8s/clusters/c-abc12"))
+				Expect(clusters.Clusters[1].Metadata).To(HaveKeyWithValue("state", "active"))
+			})
+		})
+	})
+})
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+const (
+	formatExecCredential = "execcredential"
+
+	execCredentialGroup   = "client.authentication.k8s.io"
+	execCredentialKind    = "ExecCredential"
+	execCredentialV1      = execCredentialGroup + "/v1"
+	execCredentialV1beta1 = execCredentialGroup + "/v1beta1"
+)
+
+// ExecCredential is the output kubectl expects from a client-go credential
+// plugin.
+type ExecCredential struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Status     ExecCredentialStatus `json:"status"`
+}
+
+type ExecCredentialStatus struct {
+	// ExpirationTimestamp is an RFC 3339 timestamp. kubectl calls the
+	// plugin again once it has passed.
+	ExpirationTimestamp string `json:"expirationTimestamp,omitempty"`
+	Token               string `json:"token"`
+}
+
+func newExecCredential(apiVersion string, token provider.Token) ExecCredential {
+	ec := ExecCredential{
+		APIVersion: apiVersion,
+		Kind:       execCredentialKind,
+		Status: ExecCredentialStatus{
+			Token: token.Token,
+		},
+	}
+
+	if !token.ExpiresAt.IsZero() {
+		ec.Status.ExpirationTimestamp = token.ExpiresAt.In(time.UTC).Format(time.RFC3339)
+	}
+
+	return ec
+}
+
+// execCredentialAPIVersion returns the ExecCredential apiVersion a request
+// asks for. It is set by the apiVersion query parameter, or by the v
+// parameter of an Accept header such as
+//
+//	application/json;as=ExecCredential;v=v1beta1;g=client.authentication.k8s.io
+//
+// and defaults to client.authentication.k8s.io/v1.
+func execCredentialAPIVersion(c *gin.Context) (string, error) {
+	apiVersion := c.Query("apiVersion")
+
+	if apiVersion == "" {
+		if params, ok := acceptsExecCredential(c); ok && params["v"] != "" {
+			apiVersion = execCredentialGroup + "/" + params["v"]
+		}
+	}
+
+	switch apiVersion {
+	case "", execCredentialV1:
+		return execCredentialV1, nil
+	case execCredentialV1beta1:
+		return execCredentialV1beta1, nil
+	default:
+		return "", fmt.Errorf("unsupported ExecCredential apiVersion: %s", apiVersion)
+	}
+}
+
+// acceptsExecCredential returns the parameters of the Accept header entry
+// asking for an ExecCredential, if there is one.
+func acceptsExecCredential(c *gin.Context) (map[string]string, bool) {
+	for _, accept := range strings.Split(c.GetHeader("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+
+		if params["as"] == execCredentialKind {
+			return params, true
+		}
+	}
+
+	return nil, false
+}
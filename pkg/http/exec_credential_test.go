@@ -0,0 +1,143 @@
+package http_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	arcadehttp "github.com/homedepot/arcade/pkg/http"
+	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/provider"
+	"github.com/homedepot/arcade/pkg/provider/providerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExecCredential", func() {
+	var (
+		fakeTokenProvider *providerfakes.FakeTokenProvider
+		expiresAt         time.Time
+		ec                arcadehttp.ExecCredential
+		accept            string
+	)
+
+	BeforeEach(func() {
+		expiresAt = time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		fakeTokenProvider = &providerfakes.FakeTokenProvider{}
+		fakeTokenProvider.NewTokenReturns(provider.Token{
+			ExpiresAt: expiresAt,
+			Token:     "exec-credential-token",
+		}, nil)
+
+		p := provider.NewRegistry()
+		_ = p.Register("exec-credential", "custom", fakeTokenProvider)
+
+		r := gin.New()
+		r.Use(gin.Recovery())
+		r.Use(middleware.SetProviderRegistry(p))
+		r.GET("/tokens", arcadehttp.GetToken)
+
+		svr = httptest.NewServer(r)
+		uri = svr.URL + "/tokens?provider=exec-credential&format=execcredential"
+		accept = ""
+		ec = arcadehttp.ExecCredential{}
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		res.Body.Close()
+	})
+
+	JustBeforeEach(func() {
+		req, _ = http.NewRequest(http.MethodGet, uri, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		res, err = http.DefaultClient.Do(req)
+	})
+
+	Describe("#GetToken", func() {
+		When("the format is not supported", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/tokens?provider=exec-credential&format=xml"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("unsupported format: xml"))
+			})
+		})
+
+		When("the apiVersion is not supported", func() {
+			BeforeEach(func() {
+				uri += "&apiVersion=client.authentication.k8s.io/v1alpha1"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("unsupported ExecCredential apiVersion: client.authentication.k8s.io/v1alpha1"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns a v1 ExecCredential", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &ec)).To(Succeed())
+				Expect(ec.APIVersion).To(Equal("client.authentication.k8s.io/v1"))
+				Expect(ec.Kind).To(Equal("ExecCredential"))
+				Expect(ec.Status.Token).To(Equal("exec-credential-token"))
+				Expect(ec.Status.ExpirationTimestamp).To(Equal("2030-01-02T03:04:05Z"))
+			})
+		})
+
+		When("v1beta1 is requested", func() {
+			BeforeEach(func() {
+				uri += "&apiVersion=client.authentication.k8s.io/v1beta1"
+			})
+
+			It("returns a v1beta1 ExecCredential", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &ec)).To(Succeed())
+				Expect(ec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
+			})
+		})
+
+		When("an ExecCredential is requested with the Accept header", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/tokens?provider=exec-credential"
+				accept = "application/json;as=ExecCredential;v=v1beta1;g=client.authentication.k8s.io, application/json"
+			})
+
+			It("returns an ExecCredential", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &ec)).To(Succeed())
+				Expect(ec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
+				Expect(ec.Status.Token).To(Equal("exec-credential-token"))
+			})
+		})
+
+		When("no format is requested", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/tokens?provider=exec-credential"
+			})
+
+			It("returns the token", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("exec-credential-token"))
+			})
+		})
+	})
+})
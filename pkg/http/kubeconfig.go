@@ -0,0 +1,187 @@
+package http
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/arcade/pkg/provider"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// Kubeconfig is a kubeconfig file with a single cluster, user and context.
+type Kubeconfig struct {
+	APIVersion     string         `json:"apiVersion" yaml:"apiVersion"`
+	Kind           string         `json:"kind" yaml:"kind"`
+	Clusters       []NamedCluster `json:"clusters" yaml:"clusters"`
+	Users          []NamedUser    `json:"users" yaml:"users"`
+	Contexts       []NamedContext `json:"contexts" yaml:"contexts"`
+	CurrentContext string         `json:"current-context" yaml:"current-context"`
+}
+
+type NamedCluster struct {
+	Name    string  `json:"name" yaml:"name"`
+	Cluster Cluster `json:"cluster" yaml:"cluster"`
+}
+
+type Cluster struct {
+	Server                   string `json:"server" yaml:"server"`
+	CertificateAuthorityData string `json:"certificate-authority-data,omitempty" yaml:"certificate-authority-data,omitempty"`
+	InsecureSkipTLSVerify    bool   `json:"insecure-skip-tls-verify,omitempty" yaml:"insecure-skip-tls-verify,omitempty"`
+}
+
+type NamedUser struct {
+	Name string `json:"name" yaml:"name"`
+	User User   `json:"user" yaml:"user"`
+}
+
+type User struct {
+	Token string `json:"token" yaml:"token"`
+}
+
+type NamedContext struct {
+	Name    string  `json:"name" yaml:"name"`
+	Context Context `json:"context" yaml:"context"`
+}
+
+type Context struct {
+	Cluster string `json:"cluster" yaml:"cluster"`
+	User    string `json:"user" yaml:"user"`
+}
+
+// GetKubeconfig returns a kubeconfig for a cluster of a provider instance
+// using the instance's cached token. The kubeconfig is YAML unless JSON is
+// requested with format=json or an Accept header of application/json.
+func GetKubeconfig(c *gin.Context) {
+	format := kubeconfigFormat(c)
+	if format != formatJSON && format != formatYAML {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported format: %s", format)})
+		return
+	}
+
+	instance := instanceFor(c)
+	if instance == nil {
+		return
+	}
+
+	clusters, err := clustersOf(c, instance)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	cluster, err := clusterFor(instance, clusters, c.Query("cluster"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The cluster parameter names the cluster of the kubeconfig, so it is
+	// not passed to the token provider. The cluster's own token parameters
+	// are passed instead.
+	query := c.Request.URL.Query()
+	query.Del("cluster")
+
+	for k, v := range cluster.TokenParams {
+		query[k] = v
+	}
+
+	token, _, err := cachedToken(c, instance, query)
+	if err != nil {
+		c.JSON(tokenErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	kubeconfig := newKubeconfig(instance.Name, cluster, token)
+
+	if format == formatJSON {
+		c.JSON(http.StatusOK, kubeconfig)
+		return
+	}
+
+	b, err := yaml.Marshal(kubeconfig)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/yaml", b)
+}
+
+// clusterFor returns the first of the clusters of instance with the given
+// name or ID. The name may be omitted if the instance has exactly one
+// cluster.
+func clusterFor(instance *provider.Instance, clusters []provider.Cluster, name string) (provider.Cluster, error) {
+	if name == "" {
+		if len(clusters) != 1 {
+			return provider.Cluster{}, fmt.Errorf("cluster not set for provider: %s", instance.Name)
+		}
+
+		return clusters[0], nil
+	}
+
+	for _, cluster := range clusters {
+		if cluster.Name == name || (cluster.ID != "" && cluster.ID == name) {
+			return cluster, nil
+		}
+	}
+
+	return provider.Cluster{}, fmt.Errorf("cluster not found: %s", name)
+}
+
+func kubeconfigFormat(c *gin.Context) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), "application/json") {
+		return formatJSON
+	}
+
+	return formatYAML
+}
+
+func newKubeconfig(user string, cluster provider.Cluster, token provider.Token) Kubeconfig {
+	kc := Cluster{
+		Server:                cluster.Server,
+		InsecureSkipTLSVerify: cluster.InsecureSkipTLSVerify,
+	}
+
+	if len(cluster.CertificateAuthorityData) > 0 {
+		kc.CertificateAuthorityData = base64.StdEncoding.EncodeToString(cluster.CertificateAuthorityData)
+	}
+
+	return Kubeconfig{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []NamedCluster{
+			{
+				Name:    cluster.Name,
+				Cluster: kc,
+			},
+		},
+		Users: []NamedUser{
+			{
+				Name: user,
+				User: User{Token: token.Token},
+			},
+		},
+		Contexts: []NamedContext{
+			{
+				Name: cluster.Name,
+				Context: Context{
+					Cluster: cluster.Name,
+					User:    user,
+				},
+			},
+		},
+		CurrentContext: cluster.Name,
+	}
+}
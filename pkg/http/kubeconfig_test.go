@@ -0,0 +1,289 @@
+package http_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	arcadehttp "github.com/homedepot/arcade/pkg/http"
+	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/provider"
+	"github.com/homedepot/arcade/pkg/provider/providerfakes"
+	"github.com/homedepot/arcade/pkg/rancher"
+	"github.com/homedepot/arcade/pkg/rancher/rancherfakes"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const fakeCA = "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----\n"
+
+// fakeDiscoverer is a token provider that discovers clusters.
+type fakeDiscoverer struct {
+	*providerfakes.FakeTokenProvider
+	clusters []provider.Cluster
+	err      error
+}
+
+func (d *fakeDiscoverer) Clusters(_ context.Context) ([]provider.Cluster, error) {
+	return d.clusters, d.err
+}
+
+var _ = Describe("Kubeconfig", func() {
+	var (
+		fakeTokenProvider *providerfakes.FakeTokenProvider
+		fakeClient        *rancherfakes.FakeClient
+		discoverer        *fakeDiscoverer
+		kubeconfig        arcadehttp.Kubeconfig
+		accept            string
+	)
+
+	BeforeEach(func() {
+		fakeTokenProvider = &providerfakes.FakeTokenProvider{}
+		fakeTokenProvider.NewTokenReturns(provider.Token{
+			ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+			Token:     "kubeconfig-token",
+		}, nil)
+		discoverer = &fakeDiscoverer{
+			FakeTokenProvider: &providerfakes.FakeTokenProvider{},
+			clusters: []provider.Cluster{
+				{
+					Name:   "discovered",
+					Server: "https://discovered.example.com",
+				},
+			},
+		}
+		discoverer.NewTokenReturns(provider.Token{
+			ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+			Token:     "discovered-token",
+		}, nil)
+
+		p := provider.NewRegistry()
+		_ = p.Register("kubeconfig-multi", "custom", fakeTokenProvider)
+		_ = p.RegisterClusters("kubeconfig-multi",
+			provider.Cluster{
+				Name:                     "prod",
+				Server:                   "https://prod.example.com",
+				CertificateAuthorityData: []byte(fakeCA),
+			},
+			provider.Cluster{
+				Name:                  "lab",
+				Server:                "https://lab.example.com",
+				InsecureSkipTLSVerify: true,
+			},
+		)
+		_ = p.Register("kubeconfig-discovered", "custom", discoverer)
+		_ = p.Register("kubeconfig-failing", "custom", fakeTokenProvider)
+		_ = p.RegisterClusters("kubeconfig-failing", provider.Cluster{Name: "prod"})
+		fakeClient = &rancherfakes.FakeClient{}
+		fakeClient.NewTokenReturns(rancher.KubeconfigToken{
+			ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+			Token:     "rancher-token",
+		}, nil)
+		_ = p.Register("kubeconfig-rancher", provider.TypeRancher, rancher.NewTokenProvider(fakeClient, rancher.TokenProviderOptions{}))
+		_ = p.RegisterClusters("kubeconfig-rancher", provider.Cluster{Name: "prod"})
+		fakeClient.ClustersReturns([]rancher.Cluster{
+			{ID: "c-abc12", Name: "prod-east", State: "active"},
+			{ID: "c-def34", Name: "prod-west", State: "active"},
+		}, nil)
+		fakeClient.ServerURLStub = func(id string) (string, error) {
+			return "https://rancher.example.com/k8s/clusters/" + id, nil
+		}
+		fakeClient.CADataReturns([]byte(fakeCA), nil)
+		fakeClient.NewClusterTokenReturns(rancher.KubeconfigToken{
+			ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+			Token:     "rancher-cluster-token",
+		}, nil)
+		_ = p.Register("kubeconfig-rancher-discovered", provider.TypeRancher, rancher.NewTokenProvider(fakeClient, rancher.TokenProviderOptions{}))
+
+		r := gin.New()
+		r.Use(gin.Recovery())
+		r.Use(middleware.SetProviderRegistry(p))
+		r.GET("/kubeconfig", arcadehttp.GetKubeconfig)
+
+		svr = httptest.NewServer(r)
+		uri = svr.URL + "/kubeconfig?provider=kubeconfig-multi&cluster=prod"
+		accept = ""
+		kubeconfig = arcadehttp.Kubeconfig{}
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		res.Body.Close()
+	})
+
+	JustBeforeEach(func() {
+		req, _ = http.NewRequest(http.MethodGet, uri, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		res, err = http.DefaultClient.Do(req)
+	})
+
+	Describe("#GetKubeconfig", func() {
+		When("the provider is not configured", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/kubeconfig?provider=fake"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("Unsupported token provider: fake"))
+			})
+		})
+
+		When("the format is not supported", func() {
+			BeforeEach(func() {
+				uri += "&format=xml"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("unsupported format: xml"))
+			})
+		})
+
+		When("the cluster is not set and the provider has several", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-multi"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("cluster not set for provider: kubeconfig-multi"))
+			})
+		})
+
+		When("the cluster does not exist", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-multi&cluster=fake"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("cluster not found: fake"))
+			})
+		})
+
+		When("discovering clusters fails", func() {
+			BeforeEach(func() {
+				discoverer.err = errors.New("error listing clusters")
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-discovered"
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("error discovering clusters: error listing clusters"))
+			})
+		})
+
+		When("getting the token fails", func() {
+			BeforeEach(func() {
+				fakeTokenProvider.NewTokenReturns(provider.Token{}, errors.New("error getting token"))
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-failing&cluster=prod"
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("returns a YAML kubeconfig", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(res.Header.Get("Content-Type")).To(Equal("application/yaml"))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(yaml.Unmarshal(b, &kubeconfig)).To(Succeed())
+				Expect(kubeconfig.Kind).To(Equal("Config"))
+				Expect(kubeconfig.CurrentContext).To(Equal("prod"))
+				Expect(kubeconfig.Clusters).To(HaveLen(1))
+				Expect(kubeconfig.Clusters[0].Cluster.Server).To(Equal("https://prod.example.com"))
+				Expect(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData).To(Equal(base64.StdEncoding.EncodeToString([]byte(fakeCA))))
+				Expect(kubeconfig.Users[0].Name).To(Equal("kubeconfig-multi"))
+				Expect(kubeconfig.Users[0].User.Token).To(Equal("kubeconfig-token"))
+				Expect(kubeconfig.Contexts[0].Context.Cluster).To(Equal("prod"))
+				Expect(kubeconfig.Contexts[0].Context.User).To(Equal("kubeconfig-multi"))
+			})
+		})
+
+		When("JSON is requested with the Accept header", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-multi&cluster=lab"
+				accept = "application/json"
+			})
+
+			It("returns a JSON kubeconfig", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &kubeconfig)).To(Succeed())
+				Expect(kubeconfig.Clusters[0].Cluster.Server).To(Equal("https://lab.example.com"))
+				Expect(kubeconfig.Clusters[0].Cluster.InsecureSkipTLSVerify).To(BeTrue())
+				Expect(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData).To(BeEmpty())
+			})
+		})
+
+		When("the provider discovers a single cluster", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-discovered&format=json"
+			})
+
+			It("uses it", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &kubeconfig)).To(Succeed())
+				Expect(kubeconfig.Clusters[0].Name).To(Equal("discovered"))
+				Expect(kubeconfig.Users[0].User.Token).To(Equal("discovered-token"))
+			})
+		})
+
+		When("the token provider reads a cluster parameter", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-rancher&cluster=prod&format=json"
+			})
+
+			It("does not pass the kubeconfig cluster to it", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &kubeconfig)).To(Succeed())
+				Expect(kubeconfig.Users[0].User.Token).To(Equal("rancher-token"))
+				Expect(fakeClient.NewClusterTokenCallCount()).To(Equal(0))
+			})
+		})
+
+		When("a discovered rancher cluster is requested by ID", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/kubeconfig?provider=kubeconfig-rancher-discovered&cluster=c-def34&format=json"
+			})
+
+			It("returns a kubeconfig with a token scoped to the cluster", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &kubeconfig)).To(Succeed())
+				Expect(kubeconfig.Clusters[0].Name).To(Equal("prod-west"))
+				Expect(kubeconfig.Clusters[0].Cluster.Server).To(Equal("https://rancher.example.com/k8s/clusters/c-def34"))
+				Expect(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData).To(Equal(base64.StdEncoding.EncodeToString([]byte(fakeCA))))
+				Expect(kubeconfig.Users[0].User.Token).To(Equal("rancher-cluster-token"))
+				Expect(fakeClient.NewClusterTokenCallCount()).To(Equal(1))
+				_, id := fakeClient.NewClusterTokenArgsForCall(0)
+				Expect(id).To(Equal("c-def34"))
+			})
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+// ProviderTokenResponse is a token a provider instance created upstream.
+// The token itself is never returned.
+type ProviderTokenResponse struct {
+	ID        string            `json:"id"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	IssuedAt  time.Time         `json:"issuedAt"`
+	Cached    bool              `json:"cached"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// GetProviderTokens lists the tokens the provider instance named in the
+// path created upstream, marking the ones arcade has cached.
+func GetProviderTokens(c *gin.Context) {
+	instance, tokens := providerTokens(c)
+	if instance == nil {
+		return
+	}
+
+	cached := cachedTokenIDs(instance)
+	res := make([]ProviderTokenResponse, 0, len(tokens))
+
+	for _, t := range tokens {
+		_, ok := cached[t.ID]
+		res = append(res, newProviderTokenResponse(t, ok))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": res})
+}
+
+// DeleteProviderTokens revokes the tokens the provider instance named in
+// the path created upstream that arcade has not cached, and returns them.
+func DeleteProviderTokens(c *gin.Context) {
+	instance, tokens := providerTokens(c)
+	if instance == nil {
+		return
+	}
+
+	revoker, ok := instance.TokenProvider.(provider.Revoker)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "provider does not revoke tokens: " + instance.Name})
+		return
+	}
+
+	cached := cachedTokenIDs(instance)
+	pruned := []ProviderTokenResponse{}
+	errs := []string{}
+
+	for _, t := range tokens {
+		if _, ok := cached[t.ID]; ok || t.ID == "" {
+			continue
+		}
+
+		if err := revoker.Revoke(c, t); err != nil {
+			log.Printf("error revoking token %s of provider %s: %s", t.ID, instance.Name, err.Error())
+			errs = append(errs, err.Error())
+
+			continue
+		}
+
+		pruned = append(pruned, newProviderTokenResponse(t, false))
+	}
+
+	if len(errs) > 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": strings.Join(errs, "; "), "tokens": pruned})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": pruned})
+}
+
+// providerTokens returns the instance named in the path and the tokens it
+// created upstream. If the tokens cannot be listed it writes an error
+// response and returns a nil instance.
+func providerTokens(c *gin.Context) (*provider.Instance, []provider.Token) {
+	instance := instanceNamed(c, c.Param("provider"))
+	if instance == nil {
+		return nil, nil
+	}
+
+	lister, ok := instance.TokenProvider.(provider.TokenLister)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "provider does not list tokens: " + instance.Name})
+		return nil, nil
+	}
+
+	tokens, err := lister.Tokens(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, nil
+	}
+
+	return instance, tokens
+}
+
+// cachedTokenIDs returns the IDs of the tokens cached for instance, for
+// any parameters.
+func cachedTokenIDs(instance *provider.Instance) map[string]struct{} {
+	ids := map[string]struct{}{}
+
+	for _, tc := range instance.TokenCaches() {
+		tc.Lock()
+		if tc.Token.ID != "" {
+			ids[tc.Token.ID] = struct{}{}
+		}
+		tc.Unlock()
+	}
+
+	return ids
+}
+
+func newProviderTokenResponse(t provider.Token, cached bool) ProviderTokenResponse {
+	return ProviderTokenResponse{
+		ID:        t.ID,
+		ExpiresAt: t.ExpiresAt,
+		IssuedAt:  t.IssuedAt,
+		Cached:    cached,
+		Metadata:  t.Metadata,
+	}
+}
@@ -0,0 +1,201 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	arcadehttp "github.com/homedepot/arcade/pkg/http"
+	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/provider"
+	"github.com/homedepot/arcade/pkg/provider/providerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeTokenLister is a token provider that lists and revokes the tokens
+// it created upstream.
+type fakeTokenLister struct {
+	*providerfakes.FakeTokenProvider
+	tokens    []provider.Token
+	err       error
+	revoked   []string
+	revokeErr error
+}
+
+func (f *fakeTokenLister) Tokens(_ context.Context) ([]provider.Token, error) {
+	return f.tokens, f.err
+}
+
+func (f *fakeTokenLister) Revoke(_ context.Context, t provider.Token) error {
+	if f.revokeErr != nil {
+		return f.revokeErr
+	}
+
+	f.revoked = append(f.revoked, t.ID)
+
+	return nil
+}
+
+type providerTokensResponse struct {
+	Tokens []arcadehttp.ProviderTokenResponse `json:"tokens"`
+	Error  string                             `json:"error"`
+}
+
+var _ = Describe("ProviderTokens", func() {
+	var (
+		lister   *fakeTokenLister
+		method   string
+		apiKey   string
+		response providerTokensResponse
+	)
+
+	BeforeEach(func() {
+		lister = &fakeTokenLister{
+			FakeTokenProvider: &providerfakes.FakeTokenProvider{},
+			tokens: []provider.Token{
+				{ID: "kubeconfig-u-cached"},
+				{ID: "kubeconfig-u-stale", Metadata: map[string]string{"clusterId": "c-abc12"}},
+			},
+		}
+		lister.NewTokenReturns(provider.Token{
+			Token:     "cached-token",
+			ID:        "kubeconfig-u-cached",
+			ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+		}, nil)
+
+		p := provider.NewRegistry()
+		_ = p.Register("tokens-rancher", provider.TypeRancher, lister)
+		_ = p.Register("tokens-unsupported", "custom", &providerfakes.FakeTokenProvider{})
+
+		r := gin.New()
+		r.Use(gin.Recovery())
+		r.Use(middleware.NewApiKeysAuth(map[string]string{
+			middleware.DefaultAPIKeyName: "test",
+			middleware.AdminAPIKeyName:   "admin-test",
+		}))
+		r.Use(middleware.SetProviderRegistry(p))
+		r.GET("/tokens", arcadehttp.GetToken)
+		r.GET("/providers/:provider/tokens", middleware.RequireAPIKeyName(middleware.AdminAPIKeyName), arcadehttp.GetProviderTokens)
+		r.DELETE("/providers/:provider/tokens", middleware.RequireAPIKeyName(middleware.AdminAPIKeyName), arcadehttp.DeleteProviderTokens)
+
+		svr = httptest.NewServer(r)
+		uri = svr.URL + "/providers/tokens-rancher/tokens"
+		method = http.MethodGet
+		apiKey = "admin-test"
+		response = providerTokensResponse{}
+
+		// Cache a token of the instance.
+		req, _ = http.NewRequest(http.MethodGet, svr.URL+"/tokens?provider=tokens-rancher", nil)
+		req.Header.Set("Api-Key", "test")
+		res, err = http.DefaultClient.Do(req)
+		Expect(err).To(BeNil())
+		res.Body.Close()
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		res.Body.Close()
+	})
+
+	JustBeforeEach(func() {
+		req, _ = http.NewRequest(method, uri, nil)
+		req.Header.Set("Api-Key", apiKey)
+		res, err = http.DefaultClient.Do(req)
+		b, _ := ioutil.ReadAll(res.Body)
+		_ = json.Unmarshal(b, &response)
+	})
+
+	Describe("#GetProviderTokens", func() {
+		When("the request is not authenticated with the admin API key", func() {
+			BeforeEach(func() {
+				apiKey = "test"
+			})
+
+			It("returns forbidden", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(response.Error).To(Equal("admin api key required"))
+				Expect(response.Tokens).To(BeEmpty())
+			})
+		})
+
+		When("the provider does not list tokens", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/providers/tokens-unsupported/tokens"
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(response.Error).To(Equal("provider does not list tokens: tokens-unsupported"))
+			})
+		})
+
+		When("listing tokens fails", func() {
+			BeforeEach(func() {
+				lister.err = errors.New("error listing tokens: 403 Forbidden")
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(response.Error).To(Equal("error listing tokens: 403 Forbidden"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("marks the cached tokens", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Tokens).To(HaveLen(2))
+				Expect(response.Tokens[0].ID).To(Equal("kubeconfig-u-cached"))
+				Expect(response.Tokens[0].Cached).To(BeTrue())
+				Expect(response.Tokens[1].ID).To(Equal("kubeconfig-u-stale"))
+				Expect(response.Tokens[1].Cached).To(BeFalse())
+				Expect(response.Tokens[1].Metadata).To(HaveKeyWithValue("clusterId", "c-abc12"))
+			})
+		})
+	})
+
+	Describe("#DeleteProviderTokens", func() {
+		BeforeEach(func() {
+			method = http.MethodDelete
+			apiKey = "admin-test"
+		})
+
+		When("the request is not authenticated with the admin API key", func() {
+			BeforeEach(func() {
+				apiKey = "test"
+			})
+
+			It("returns forbidden", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(response.Error).To(Equal("admin api key required"))
+				Expect(lister.revoked).To(BeEmpty())
+			})
+		})
+
+		When("revoking a token fails", func() {
+			BeforeEach(func() {
+				lister.revokeErr = errors.New("error deleting token kubeconfig-u-stale: 403 Forbidden")
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(response.Error).To(Equal("error deleting token kubeconfig-u-stale: 403 Forbidden"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("revokes the tokens that are not cached", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Tokens).To(HaveLen(1))
+				Expect(response.Tokens[0].ID).To(Equal("kubeconfig-u-stale"))
+				Expect(lister.revoked).To(Equal([]string{"kubeconfig-u-stale"}))
+			})
+		})
+	})
+})
@@ -1,76 +1,242 @@
 package http
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
-	"sync"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/homedepot/arcade/pkg/google"
-	"github.com/homedepot/arcade/pkg/rancher"
+	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/provider"
 )
 
-var (
-	err             error
-	googleMux       sync.Mutex
-	t               time.Time
-	token           string
-	expiration      = 1 * time.Minute
-	rancherMux      sync.Mutex
-	kubeconfigToken rancher.KubeconfigToken
-)
+// expired returns true if the token of tc is not set or expires within the
+// expiry skew of instance.
+func expired(instance *provider.Instance, tc *provider.TokenCache) bool {
+	skew := provider.DefaultExpirySkew
+	if skewer, ok := instance.TokenProvider.(provider.ExpirySkewer); ok {
+		skew = skewer.ExpirySkew()
+	}
 
+	return tc.Token.Token == "" || !time.Now().In(time.UTC).Add(skew).Before(tc.Token.ExpiresAt)
+}
+
+// GetToken returns the cached token of a provider instance. The token is
+// returned as an ExecCredential, for use by a kubectl credential plugin,
+// when requested with format=execcredential or an Accept header asking
+// for one, and with its expiry and metadata when requested with
+// format=detailed.
 func GetToken(c *gin.Context) {
-	provider := c.Query("provider")
-
-	switch provider {
-	case "rancher":
-		getRancherToken(c)
-	case "google", "":
-		getGoogleToken(c)
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unsupported token provider: %s", provider)})
+	format := tokenFormat(c)
+	if format != "" && format != formatExecCredential && format != formatDetailed {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported format: %s", format)})
 		return
 	}
-}
 
-func getGoogleToken(c *gin.Context) {
-	googleMux.Lock()
-	defer googleMux.Unlock()
+	var apiVersion string
 
-	if time.Since(t) > expiration || token == "" {
-		googleClient := google.Instance(c)
+	if format == formatExecCredential {
+		var err error
 
-		token, err = googleClient.NewToken()
+		apiVersion, err = execCredentialAPIVersion(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+	}
+
+	instance := instanceFor(c)
+	if instance == nil {
+		return
+	}
 
-		t = time.Now().In(time.UTC)
+	token, cachedAt, err := cachedToken(c, instance, c.Request.URL.Query())
+	if err != nil {
+		c.JSON(tokenErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	switch format {
+	case formatExecCredential:
+		c.JSON(http.StatusOK, newExecCredential(apiVersion, token))
+		return
+	case formatDetailed:
+		c.JSON(http.StatusOK, newTokenResponse(instance, token, cachedAt))
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	c.JSON(http.StatusOK, gin.H{"token": token.Token})
 }
 
-func getRancherToken(c *gin.Context) {
-	if time.Now().In(time.UTC).After(kubeconfigToken.ExpiresAt) || kubeconfigToken.Token == "" {
-		rancherMux.Lock()
-		defer rancherMux.Unlock()
+// tokenErrorStatus returns the status of the response to a request whose
+// token could not be created.
+func tokenErrorStatus(err error) int {
+	if errors.Is(err, provider.ErrInvalidParams) {
+		return http.StatusBadRequest
+	}
 
-		rancherClient := rancher.Instance(c)
-		if rancherClient == nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "token provider not configured: rancher"})
-			return
+	return http.StatusInternalServerError
+}
+
+func tokenFormat(c *gin.Context) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+
+	if _, ok := acceptsExecCredential(c); ok {
+		return formatExecCredential
+	}
+
+	return ""
+}
+
+// instanceFor returns the provider instance named by the provider query
+// parameter. If the instance is not configured it writes an error response
+// and returns nil.
+func instanceFor(c *gin.Context) *provider.Instance {
+	name := c.Query("provider")
+	if name == "" {
+		name = provider.DefaultName
+	}
+
+	return instanceNamed(c, name)
+}
+
+// instanceNamed returns the provider instance with the given name. If the
+// instance is not configured, or the caller's API key may not use it, it
+// writes an error response and returns nil.
+func instanceNamed(c *gin.Context, name string) *provider.Instance {
+	instance := provider.Get(c, name)
+	if instance == nil {
+		if provider.IsType(name) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("token provider not configured: %s", name)})
+			return nil
+		}
+
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unsupported token provider: %s", name)})
+
+		return nil
+	}
+
+	apiKeyName := c.GetString(middleware.APIKeyNameKey)
+	if !provider.Allows(c, apiKeyName, name) {
+		c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("api key %s may not use token provider: %s", apiKeyName, name)})
+		return nil
+	}
+
+	return instance
+}
+
+// cachedToken returns the cached token of instance and when it was cached,
+// creating a new one if it has expired. Tokens of a
+// provider.ParamsTokenProvider are cached per parameter values, which are
+// read from query.
+func cachedToken(c *gin.Context, instance *provider.Instance, query url.Values) (provider.Token, time.Time, error) {
+	params := url.Values{}
+
+	ptp, hasParams := instance.TokenProvider.(provider.ParamsTokenProvider)
+	if hasParams {
+		for _, param := range ptp.Params() {
+			if param == provider.ParamAPIKey {
+				if name := c.GetString(middleware.APIKeyNameKey); name != "" {
+					params.Set(param, name)
+				}
+
+				continue
+			}
+
+			if values, ok := query[param]; ok {
+				params[param] = values
+			}
+		}
+
+		if normalizer, ok := ptp.(provider.ParamsNormalizer); ok {
+			params = normalizer.NormalizeParams(params)
+		}
+	}
+
+	tc := instance.TokenCache(params.Encode())
+
+	tc.Lock()
+	defer tc.Unlock()
+
+	if expired(instance, tc) || !validToken(c, instance, tc) {
+		var (
+			token provider.Token
+			err   error
+		)
+
+		if hasParams {
+			token, err = ptp.NewTokenWithParams(c, params)
+		} else {
+			token, err = instance.TokenProvider.NewToken(c)
 		}
 
-		kubeconfigToken, err = rancherClient.NewToken(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+			return provider.Token{}, time.Time{}, err
+		}
+
+		now := time.Now().In(time.UTC)
+		if token.IssuedAt.IsZero() {
+			token.IssuedAt = now
+		}
+
+		// Upstreams may return the token they returned before, for example
+		// Rancher while the kubeconfig token of a user is still valid, so it
+		// is only revoked once it has been replaced by another token.
+		if revoker, ok := instance.TokenProvider.(provider.Revoker); ok && tc.Token.Token != "" && !sameToken(tc.Token, token) {
+			if err := revoker.Revoke(c, tc.Token); err != nil {
+				log.Printf("error revoking token %s of provider %s: %s", tc.Token.ID, instance.Name, err.Error())
+			}
 		}
+
+		tc.Token = token
+		tc.CachedAt = now
+		tc.ValidatedAt = now
+	}
+
+	return tc.Token, tc.CachedAt, nil
+}
+
+// sameToken returns true if a and b are the same upstream token.
+func sameToken(a, b provider.Token) bool {
+	if a.ID != "" || b.ID != "" {
+		return a.ID == b.ID
+	}
+
+	return a.Token == b.Token
+}
+
+// validToken returns false if the provider of instance is a
+// provider.TokenValidator and the cached token is no longer valid upstream.
+// Tokens are validated once per validation interval. Tokens that cannot be
+// validated, for example because the upstream is unreachable, are served.
+func validToken(c *gin.Context, instance *provider.Instance, tc *provider.TokenCache) bool {
+	validator, ok := instance.TokenProvider.(provider.TokenValidator)
+	if !ok || validator.ValidationInterval() <= 0 {
+		return true
+	}
+
+	now := time.Now().In(time.UTC)
+	if now.Sub(tc.ValidatedAt) < validator.ValidationInterval() {
+		return true
+	}
+
+	tc.ValidatedAt = now
+
+	valid, err := validator.Validate(c, tc.Token)
+	if err != nil {
+		log.Printf("error validating token %s of provider %s: %s", tc.Token.ID, instance.Name, err.Error())
+		return true
+	}
+
+	if !valid {
+		log.Printf("token %s of provider %s is no longer valid, replacing it", tc.Token.ID, instance.Name)
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": kubeconfigToken.Token})
+	return valid
 }
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+const (
+	formatDetailed = "detailed"
+)
+
+// TokenResponse is a token with its expiry and metadata.
+type TokenResponse struct {
+	Token string `json:"token"`
+	// Provider is the name of the provider instance.
+	Provider  string    `json:"provider"`
+	Type      string    `json:"type"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	CachedAt  time.Time `json:"cachedAt"`
+	// Fingerprint identifies the token in logs without revealing it.
+	Fingerprint string `json:"fingerprint"`
+	// ID is the upstream identifier of the token, for example a Rancher
+	// token ID.
+	ID       string            `json:"id,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func newTokenResponse(instance *provider.Instance, token provider.Token, cachedAt time.Time) TokenResponse {
+	return TokenResponse{
+		Token:       token.Token,
+		Provider:    instance.Name,
+		Type:        instance.Type,
+		ExpiresAt:   token.ExpiresAt,
+		IssuedAt:    token.IssuedAt,
+		CachedAt:    cachedAt,
+		Fingerprint: fingerprint(token.Token),
+		ID:          token.ID,
+		Metadata:    token.Metadata,
+	}
+}
+
+// fingerprint returns the hex encoded SHA-256 hash of a token.
+func fingerprint(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
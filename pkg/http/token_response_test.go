@@ -0,0 +1,100 @@
+package http_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	arcadehttp "github.com/homedepot/arcade/pkg/http"
+	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/provider"
+	"github.com/homedepot/arcade/pkg/provider/providerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenResponse", func() {
+	var (
+		fakeTokenProvider *providerfakes.FakeTokenProvider
+		expiresAt         time.Time
+		issuedAt          time.Time
+		tr                arcadehttp.TokenResponse
+	)
+
+	BeforeEach(func() {
+		expiresAt = time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		issuedAt = time.Date(2030, 1, 1, 3, 4, 5, 0, time.UTC)
+		fakeTokenProvider = &providerfakes.FakeTokenProvider{}
+		fakeTokenProvider.NewTokenReturns(provider.Token{
+			ExpiresAt: expiresAt,
+			IssuedAt:  issuedAt,
+			Token:     "detailed-token",
+			ID:        "kubeconfig-u-abc12",
+			Metadata: map[string]string{
+				"userId": "u-abc12",
+			},
+		}, nil)
+
+		p := provider.NewRegistry()
+		_ = p.Register("detailed", provider.TypeRancher, fakeTokenProvider)
+
+		r := gin.New()
+		r.Use(gin.Recovery())
+		r.Use(middleware.SetProviderRegistry(p))
+		r.GET("/tokens", arcadehttp.GetToken)
+
+		svr = httptest.NewServer(r)
+		uri = svr.URL + "/tokens?provider=detailed&format=detailed"
+		tr = arcadehttp.TokenResponse{}
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		res.Body.Close()
+	})
+
+	JustBeforeEach(func() {
+		req, _ = http.NewRequest(http.MethodGet, uri, nil)
+		res, err = http.DefaultClient.Do(req)
+	})
+
+	Describe("#GetToken", func() {
+		When("it succeeds", func() {
+			It("returns the token with its expiry and metadata", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				Expect(json.Unmarshal(b, &tr)).To(Succeed())
+				sum := sha256.Sum256([]byte("detailed-token"))
+				Expect(tr.Token).To(Equal("detailed-token"))
+				Expect(tr.Provider).To(Equal("detailed"))
+				Expect(tr.Type).To(Equal(provider.TypeRancher))
+				Expect(tr.ExpiresAt).To(Equal(expiresAt))
+				Expect(tr.IssuedAt).To(Equal(issuedAt))
+				Expect(tr.CachedAt).To(BeTemporally("~", time.Now(), 5*time.Second))
+				Expect(tr.Fingerprint).To(Equal("sha256:" + hex.EncodeToString(sum[:])))
+				Expect(tr.ID).To(Equal("kubeconfig-u-abc12"))
+				Expect(tr.Metadata).To(HaveKeyWithValue("userId", "u-abc12"))
+			})
+		})
+
+		When("no format is requested", func() {
+			BeforeEach(func() {
+				uri = svr.URL + "/tokens?provider=detailed"
+			})
+
+			It("returns only the token", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				m := map[string]interface{}{}
+				_ = json.Unmarshal(b, &m)
+				Expect(m).To(Equal(map[string]interface{}{"token": "detailed-token"}))
+			})
+		})
+	})
+})
@@ -2,21 +2,39 @@ package http_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/homedepot/arcade/pkg/aws"
+	"github.com/homedepot/arcade/pkg/aws/awsfakes"
+	"github.com/homedepot/arcade/pkg/azure"
+	"github.com/homedepot/arcade/pkg/azure/azurefakes"
+	"github.com/homedepot/arcade/pkg/exec"
+	"github.com/homedepot/arcade/pkg/exec/execfakes"
+	"github.com/homedepot/arcade/pkg/google"
 	"github.com/homedepot/arcade/pkg/google/googlefakes"
 	arcadehttp "github.com/homedepot/arcade/pkg/http"
+	"github.com/homedepot/arcade/pkg/kubernetes"
+	"github.com/homedepot/arcade/pkg/kubernetes/kubernetesfakes"
 	"github.com/homedepot/arcade/pkg/middleware"
+	"github.com/homedepot/arcade/pkg/oidc"
+	"github.com/homedepot/arcade/pkg/oidc/oidcfakes"
+	"github.com/homedepot/arcade/pkg/provider"
+	"github.com/homedepot/arcade/pkg/provider/providerfakes"
 	"github.com/homedepot/arcade/pkg/rancher"
 	"github.com/homedepot/arcade/pkg/rancher/rancherfakes"
+	"github.com/homedepot/arcade/pkg/vault"
+	"github.com/homedepot/arcade/pkg/vault/vaultfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
 )
 
 type Tokens struct {
@@ -25,15 +43,18 @@ type Tokens struct {
 }
 
 var (
-	err               error
-	svr               *httptest.Server
-	uri               string
-	req               *http.Request
-	body              *bytes.Buffer
-	res               *http.Response
-	tokens            Tokens
-	fakeGoogleClient  *googlefakes.FakeClient
-	fakeGoogleToken   = "fake-google-token"
+	err              error
+	svr              *httptest.Server
+	uri              string
+	req              *http.Request
+	body             *bytes.Buffer
+	res              *http.Response
+	tokens           Tokens
+	fakeGoogleClient *googlefakes.FakeClient
+	fakeGoogleToken  = &oauth2.Token{
+		AccessToken: "fake-google-token",
+		Expiry:      time.Now().In(time.UTC).Add(1 * time.Hour),
+	}
 	fakeRancherClient *rancherfakes.FakeClient
 	fakeRancherToken  = rancher.KubeconfigToken{
 		Token: "fake-rancher-token",
@@ -42,10 +63,93 @@ var (
 		ExpiresAt: time.Now().In(time.UTC).Add(-24 * time.Hour),
 		Token:     "expired-rancher-token",
 	}
+	// expiringRancherToken expires within the expiry skew of the cache.
+	expiringRancherToken = rancher.KubeconfigToken{
+		ExpiresAt: time.Now().In(time.UTC).Add(30 * time.Second),
+		Token:     "expiring-rancher-token",
+	}
 	validRancherToken = rancher.KubeconfigToken{
 		ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
 		Token:     "valid-rancher-token",
 	}
+	fakeAWSClient *awsfakes.FakeClient
+	fakeAWSToken  = aws.Token{
+		Token: "fake-aws-token",
+	}
+	expiredAWSToken = aws.Token{
+		ExpiresAt: time.Now().In(time.UTC).Add(-24 * time.Hour),
+		Token:     "expired-aws-token",
+	}
+	validAWSToken = aws.Token{
+		ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+		Token:     "valid-aws-token",
+	}
+	fakeAzureClient *azurefakes.FakeClient
+	fakeAzureToken  = azure.Token{
+		Token: "fake-azure-token",
+	}
+	expiredAzureToken = azure.Token{
+		ExpiresAt: time.Now().In(time.UTC).Add(-24 * time.Hour),
+		Token:     "expired-azure-token",
+	}
+	// expiringAzureToken expires within the expiry skew of the provider.
+	expiringAzureToken = azure.Token{
+		ExpiresAt: time.Now().In(time.UTC).Add(30 * time.Second),
+		Token:     "expiring-azure-token",
+	}
+	validAzureToken = azure.Token{
+		ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+		Token:     "valid-azure-token",
+	}
+	fakeKubernetesClient *kubernetesfakes.FakeClient
+	fakeTokenRequest     = kubernetes.TokenRequest{
+		Status: kubernetes.TokenRequestStatus{
+			Token: "fake-kubernetes-token",
+		},
+	}
+	expiringTokenRequest = kubernetes.TokenRequest{
+		Status: kubernetes.TokenRequestStatus{
+			ExpirationTimestamp: time.Now().In(time.UTC).Add(30 * time.Second),
+			Token:               "expiring-kubernetes-token",
+		},
+	}
+	validTokenRequest = kubernetes.TokenRequest{
+		Status: kubernetes.TokenRequestStatus{
+			ExpirationTimestamp: time.Now().In(time.UTC).Add(1 * time.Hour),
+			Token:               "valid-kubernetes-token",
+		},
+	}
+	fakeVaultClient *vaultfakes.FakeClient
+	fakeOIDCClient  *oidcfakes.FakeClient
+	fakeOIDCToken   = oidc.Token{
+		Token: "fake-oidc-token",
+	}
+	expiredOIDCToken = oidc.Token{
+		ExpiresAt: time.Now().In(time.UTC).Add(-24 * time.Hour),
+		Token:     "expired-oidc-token",
+	}
+	validOIDCToken = oidc.Token{
+		ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+		Token:     "valid-oidc-token",
+	}
+	fakeExecClient     *execfakes.FakeClient
+	fakeExecCredential = exec.ExecCredential{
+		Status: exec.ExecCredentialStatus{
+			Token: "fake-exec-token",
+		},
+	}
+	expiredExecCredential = exec.ExecCredential{
+		Status: exec.ExecCredentialStatus{
+			ExpirationTimestamp: time.Now().In(time.UTC).Add(-24 * time.Hour),
+			Token:               "expired-exec-token",
+		},
+	}
+	validExecCredential = exec.ExecCredential{
+		Status: exec.ExecCredentialStatus{
+			ExpirationTimestamp: time.Now().In(time.UTC).Add(1 * time.Hour),
+			Token:               "valid-exec-token",
+		},
+	}
 )
 
 var _ = Describe("Token", func() {
@@ -80,6 +184,117 @@ var _ = Describe("Token", func() {
 		})
 	})
 
+	Describe("#GetToken with named providers", func() {
+		var fakeRancherProdClient, fakeRancherLabClient *rancherfakes.FakeClient
+
+		BeforeEach(func() {
+			fakeRancherProdClient = &rancherfakes.FakeClient{}
+			fakeRancherProdClient.NewTokenReturns(rancher.KubeconfigToken{
+				ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+				Token:     "rancher-prod-token",
+			}, nil)
+			fakeRancherLabClient = &rancherfakes.FakeClient{}
+			fakeRancherLabClient.NewTokenReturns(rancher.KubeconfigToken{
+				ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+				Token:     "rancher-lab-token",
+			}, nil)
+
+			p := provider.NewRegistry()
+			_ = p.Register("rancher-prod", provider.TypeRancher, rancher.NewTokenProvider(fakeRancherProdClient, rancher.TokenProviderOptions{}))
+			_ = p.Register("rancher-lab", provider.TypeRancher, rancher.NewTokenProvider(fakeRancherLabClient, rancher.TokenProviderOptions{}))
+
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(p))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+		})
+
+		AfterEach(func() {
+			svr.Close()
+		})
+
+		getToken := func(name string) Tokens {
+			t := Tokens{}
+			res, err := http.Get(svr.URL + "/tokens?provider=" + name)
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(res.Body)
+			_ = json.Unmarshal(b, &t)
+
+			return t
+		}
+
+		It("resolves each name to its own instance", func() {
+			Expect(getToken("rancher-prod").Token).To(Equal("rancher-prod-token"))
+			Expect(getToken("rancher-lab").Token).To(Equal("rancher-lab-token"))
+		})
+
+		It("caches tokens per instance", func() {
+			getToken("rancher-prod")
+			getToken("rancher-prod")
+			getToken("rancher-lab")
+			Expect(getToken("rancher-lab").Token).To(Equal("rancher-lab-token"))
+			Expect(getToken("rancher-prod").Token).To(Equal("rancher-prod-token"))
+			Expect(fakeRancherProdClient.NewTokenCallCount()).To(Equal(1))
+			Expect(fakeRancherLabClient.NewTokenCallCount()).To(Equal(1))
+		})
+
+		When("the provider type is used as a name but not configured", func() {
+			It("returns a bad request error", func() {
+				res, err := http.Get(svr.URL + "/tokens?provider=rancher")
+				Expect(err).To(BeNil())
+				defer res.Body.Close()
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("token provider not configured: rancher"))
+			})
+		})
+	})
+
+	Describe("#GetToken with an in-house provider", func() {
+		var fakeTokenProvider *providerfakes.FakeTokenProvider
+
+		BeforeEach(func() {
+			fakeTokenProvider = &providerfakes.FakeTokenProvider{}
+			fakeTokenProvider.NewTokenReturns(provider.Token{
+				ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+				Token:     "in-house-token",
+			}, nil)
+
+			p := provider.NewRegistry()
+			_ = p.Register("in-house", "custom", fakeTokenProvider)
+
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(p))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+			uri = svr.URL + "/tokens?provider=in-house"
+		})
+
+		AfterEach(func() {
+			svr.Close()
+			res.Body.Close()
+		})
+
+		JustBeforeEach(func() {
+			req, _ = http.NewRequest(http.MethodGet, uri, nil)
+			res, err = http.DefaultClient.Do(req)
+		})
+
+		It("serves tokens from the registered provider", func() {
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(res.Body)
+			_ = json.Unmarshal(b, &tokens)
+			Expect(tokens.Token).To(Equal("in-house-token"))
+		})
+	})
+
 	Describe("#GetGoogleToken", func() {
 		BeforeEach(func() {
 			fakeGoogleClient = &googlefakes.FakeClient{}
@@ -89,7 +304,7 @@ var _ = Describe("Token", func() {
 			// This disables request logging which we don't want for tests.
 			r := gin.New()
 			r.Use(gin.Recovery())
-			r.Use(middleware.SetGoogleClient(fakeGoogleClient))
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeGoogle, google.NewTokenProvider(fakeGoogleClient, google.TokenProviderOptions{ExpirySkew: google.DefaultExpirySkew}))))
 			r.GET("/tokens", arcadehttp.GetToken)
 
 			svr = httptest.NewServer(r)
@@ -109,7 +324,7 @@ var _ = Describe("Token", func() {
 
 		When("getting a new token from google fails", func() {
 			BeforeEach(func() {
-				fakeGoogleClient.NewTokenReturns("", errors.New("error getting token from google"))
+				fakeGoogleClient.NewTokenReturns(nil, errors.New("error getting token from google"))
 			})
 
 			It("returns an internal server error", func() {
@@ -143,6 +358,183 @@ var _ = Describe("Token", func() {
 		})
 	})
 
+	Describe("#GetGoogleToken with params", func() {
+		BeforeEach(func() {
+			fakeGoogleClient = &googlefakes.FakeClient{}
+			fakeGoogleClient.NewTokenReturns(fakeGoogleToken, nil)
+			fakeGoogleClient.NewImpersonatedTokenStub = func(_ context.Context, i google.Impersonation) (*oauth2.Token, error) {
+				return &oauth2.Token{
+					AccessToken: "token-of-" + i.TargetPrincipal,
+					Expiry:      time.Now().In(time.UTC).Add(1 * time.Hour),
+				}, nil
+			}
+
+			p := provider.NewRegistry()
+			_ = p.Register("gcp-impersonation", provider.TypeGoogle, google.NewTokenProvider(fakeGoogleClient, google.TokenProviderOptions{
+				ExpirySkew: google.DefaultExpirySkew,
+				AllowedServiceAccounts: []string{
+					"a@p.iam.gserviceaccount.com",
+					"b@p.iam.gserviceaccount.com",
+					"c@p.iam.gserviceaccount.com",
+					"d1@p.iam.gserviceaccount.com",
+					"d2@p.iam.gserviceaccount.com",
+				},
+				AllowedScopes: []string{
+					"https://www.googleapis.com/auth/devstorage.read_only",
+					"https://www.googleapis.com/auth/userinfo.email",
+				},
+			}))
+
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(p))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+		})
+
+		AfterEach(func() {
+			svr.Close()
+		})
+
+		getToken := func(query string) Tokens {
+			t := Tokens{}
+			res, err := http.Get(svr.URL + "/tokens?provider=gcp-impersonation" + query)
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(res.Body)
+			_ = json.Unmarshal(b, &t)
+
+			return t
+		}
+
+		It("caches tokens per impersonated service account", func() {
+			Expect(getToken("&impersonate=a@p.iam.gserviceaccount.com").Token).To(Equal("token-of-a@p.iam.gserviceaccount.com"))
+			Expect(getToken("&impersonate=b@p.iam.gserviceaccount.com").Token).To(Equal("token-of-b@p.iam.gserviceaccount.com"))
+			Expect(getToken("&impersonate=a@p.iam.gserviceaccount.com").Token).To(Equal("token-of-a@p.iam.gserviceaccount.com"))
+			Expect(getToken("").Token).To(Equal("fake-google-token"))
+			Expect(fakeGoogleClient.NewImpersonatedTokenCallCount()).To(Equal(2))
+		})
+
+		It("returns a bad request error for invalid params", func() {
+			res, err := http.Get(svr.URL + "/tokens?provider=gcp-impersonation&type=idtoken")
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+			b, _ := ioutil.ReadAll(res.Body)
+			_ = json.Unmarshal(b, &tokens)
+			Expect(tokens.Error).To(Equal("invalid parameters: audience not set"))
+		})
+
+		It("returns a bad request error for service accounts that are not allowed", func() {
+			res, err := http.Get(svr.URL + "/tokens?provider=gcp-impersonation&impersonate=owner@p.iam.gserviceaccount.com")
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(fakeGoogleClient.NewImpersonatedTokenCallCount()).To(Equal(0))
+		})
+
+		It("caches tokens per set of scopes", func() {
+			fakeGoogleClient.NewScopedTokenReturns(&oauth2.Token{
+				AccessToken: "scoped-token",
+				Expiry:      time.Now().In(time.UTC).Add(1 * time.Hour),
+			}, nil)
+			getToken("&scope=https://www.googleapis.com/auth/userinfo.email&scope=https://www.googleapis.com/auth/devstorage.read_only")
+			Expect(getToken("&scope=https://www.googleapis.com/auth/devstorage.read_only&scope=https://www.googleapis.com/auth/userinfo.email").Token).To(Equal("scoped-token"))
+			Expect(fakeGoogleClient.NewScopedTokenCallCount()).To(Equal(1))
+		})
+
+		It("passes the delegation chain", func() {
+			getToken("&impersonate=c@p.iam.gserviceaccount.com&delegate=d1@p.iam.gserviceaccount.com&delegate=d2@p.iam.gserviceaccount.com")
+			_, i := fakeGoogleClient.NewImpersonatedTokenArgsForCall(0)
+			Expect(i.TargetPrincipal).To(Equal("c@p.iam.gserviceaccount.com"))
+			Expect(i.Delegates).To(Equal([]string{"d1@p.iam.gserviceaccount.com", "d2@p.iam.gserviceaccount.com"}))
+		})
+	})
+
+	Describe("#GetGoogleToken with access boundaries", func() {
+		BeforeEach(func() {
+			fakeGoogleClient = &googlefakes.FakeClient{}
+			fakeGoogleClient.NewTokenReturns(fakeGoogleToken, nil)
+			fakeGoogleClient.NewDownscopedTokenReturns(&oauth2.Token{
+				AccessToken: "downscoped-token",
+				Expiry:      time.Now().In(time.UTC).Add(1 * time.Hour),
+			}, nil)
+
+			p := provider.NewRegistry()
+			_ = p.Register("gcp-boundaries", provider.TypeGoogle, google.NewTokenProvider(fakeGoogleClient, google.TokenProviderOptions{
+				ExpirySkew: google.DefaultExpirySkew,
+				AccessBoundaries: map[string]google.AccessBoundary{
+					"artifacts": {
+						AccessBoundaryRules: []google.AccessBoundaryRule{
+							{AvailableResource: "//storage.googleapis.com/projects/_/buckets/artifacts"},
+						},
+					},
+				},
+			}))
+			_ = p.Register("gcp-unbounded", provider.TypeGoogle, google.NewTokenProvider(fakeGoogleClient, google.TokenProviderOptions{
+				ExpirySkew: google.DefaultExpirySkew,
+			}))
+			p.RestrictAPIKey("artifacts", "gcp-boundaries")
+
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.NewApiKeysAuth(map[string]string{
+				"default":   "default-key",
+				"artifacts": "artifacts-key",
+			}))
+			r.Use(middleware.SetProviderRegistry(p))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+		})
+
+		AfterEach(func() {
+			svr.Close()
+		})
+
+		getToken := func(apiKey, query string) Tokens {
+			t := Tokens{}
+			req, _ := http.NewRequest(http.MethodGet, svr.URL+"/tokens?provider=gcp-boundaries"+query, nil)
+			req.Header.Set("Api-Key", apiKey)
+			res, err := http.DefaultClient.Do(req)
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(res.Body)
+			_ = json.Unmarshal(b, &t)
+
+			return t
+		}
+
+		It("downscopes tokens per API key", func() {
+			Expect(getToken("artifacts-key", "").Token).To(Equal("downscoped-token"))
+			Expect(getToken("default-key", "").Token).To(Equal("fake-google-token"))
+			Expect(getToken("artifacts-key", "").Token).To(Equal("downscoped-token"))
+			Expect(fakeGoogleClient.NewDownscopedTokenCallCount()).To(Equal(1))
+		})
+
+		It("ignores an apiKey query parameter", func() {
+			Expect(getToken("default-key", "&apiKey=artifacts").Token).To(Equal("fake-google-token"))
+			Expect(fakeGoogleClient.NewDownscopedTokenCallCount()).To(Equal(0))
+		})
+
+		It("refuses the API key on instances without its access boundary", func() {
+			req, _ := http.NewRequest(http.MethodGet, svr.URL+"/tokens?provider=gcp-unbounded", nil)
+			req.Header.Set("Api-Key", "artifacts-key")
+			res, err := http.DefaultClient.Do(req)
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+			b, _ := ioutil.ReadAll(res.Body)
+			t := Tokens{}
+			_ = json.Unmarshal(b, &t)
+			Expect(t.Error).To(Equal("api key artifacts may not use token provider: gcp-unbounded"))
+			Expect(fakeGoogleClient.NewTokenCallCount()).To(Equal(0))
+		})
+	})
+
 	Describe("#GetRancherToken", func() {
 		BeforeEach(func() {
 			fakeRancherClient = &rancherfakes.FakeClient{}
@@ -152,7 +544,7 @@ var _ = Describe("Token", func() {
 			// This disables request logging which we don't want for tests.
 			r := gin.New()
 			r.Use(gin.Recovery())
-			r.Use(middleware.SetRancherClient(fakeRancherClient))
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeRancher, rancher.NewTokenProvider(fakeRancherClient, rancher.TokenProviderOptions{}))))
 			r.GET("/tokens", arcadehttp.GetToken)
 
 			svr = httptest.NewServer(r)
@@ -224,6 +616,25 @@ var _ = Describe("Token", func() {
 			})
 		})
 
+		When("token expires within the expiry skew", func() {
+			BeforeEach(func() {
+				// Call first time to get the expiring token.
+				fakeRancherClient.NewTokenReturns(expiringRancherToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeRancherClient.NewTokenReturns(validRancherToken, nil)
+			})
+
+			It("is not served from the cache", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-rancher-token"))
+				Expect(fakeRancherClient.NewTokenCallCount()).To(Equal(2))
+			})
+		})
+
 		When("it succeeds", func() {
 			It("succeeds", func() {
 				Expect(res.StatusCode).To(Equal(http.StatusOK))
@@ -233,4 +644,743 @@ var _ = Describe("Token", func() {
 			})
 		})
 	})
-})
+
+	Describe("#GetRancherToken with clusters", func() {
+		BeforeEach(func() {
+			fakeRancherClient = &rancherfakes.FakeClient{}
+			fakeRancherClient.NewTokenReturns(validRancherToken, nil)
+			fakeRancherClient.NewClusterTokenStub = func(_ context.Context, clusterID string) (rancher.KubeconfigToken, error) {
+				return rancher.KubeconfigToken{
+					Token:     "token-for-" + clusterID,
+					ClusterID: clusterID,
+					ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+				}, nil
+			}
+
+			p := provider.NewRegistry()
+			_ = p.Register("rancher-clusters", provider.TypeRancher, rancher.NewTokenProvider(fakeRancherClient, rancher.TokenProviderOptions{}))
+
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(p))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+		})
+
+		AfterEach(func() {
+			svr.Close()
+		})
+
+		getToken := func(query string) Tokens {
+			t := Tokens{}
+			res, err := http.Get(svr.URL + "/tokens?provider=rancher-clusters" + query)
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(res.Body)
+			_ = json.Unmarshal(b, &t)
+
+			return t
+		}
+
+		It("caches tokens per cluster", func() {
+			Expect(getToken("&cluster=c-abc12").Token).To(Equal("token-for-c-abc12"))
+			Expect(getToken("&cluster=c-def34").Token).To(Equal("token-for-c-def34"))
+			Expect(getToken("&cluster=c-abc12").Token).To(Equal("token-for-c-abc12"))
+			Expect(getToken("").Token).To(Equal("valid-rancher-token"))
+			Expect(fakeRancherClient.NewClusterTokenCallCount()).To(Equal(2))
+			Expect(fakeRancherClient.NewTokenCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("#GetRancherToken with validation", func() {
+		BeforeEach(func() {
+			fakeRancherClient = &rancherfakes.FakeClient{}
+			fakeRancherClient.NewTokenStub = func(_ context.Context) (rancher.KubeconfigToken, error) {
+				n := fakeRancherClient.NewTokenCallCount()
+
+				// Tokens never expire.
+				return rancher.KubeconfigToken{
+					ID:    fmt.Sprintf("kubeconfig-u-%d", n),
+					Token: fmt.Sprintf("rancher-token-%d", n),
+				}, nil
+			}
+			fakeRancherClient.TokenReturns(rancher.KubeconfigToken{Enabled: true}, nil)
+
+			p := provider.NewRegistry()
+			_ = p.Register("rancher-validation", provider.TypeRancher, rancher.NewTokenProvider(fakeRancherClient, rancher.TokenProviderOptions{
+				MaxCacheAge:        time.Hour,
+				ValidationInterval: time.Nanosecond,
+			}))
+
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(p))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+		})
+
+		AfterEach(func() {
+			svr.Close()
+		})
+
+		getToken := func() Tokens {
+			t := Tokens{}
+			res, err := http.Get(svr.URL + "/tokens?provider=rancher-validation")
+			Expect(err).To(BeNil())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(res.Body)
+			_ = json.Unmarshal(b, &t)
+
+			return t
+		}
+
+		When("the cached token is valid", func() {
+			It("caches tokens that never expire", func() {
+				Expect(getToken().Token).To(Equal("rancher-token-1"))
+				Expect(getToken().Token).To(Equal("rancher-token-1"))
+				Expect(fakeRancherClient.NewTokenCallCount()).To(Equal(1))
+				Expect(fakeRancherClient.TokenCallCount()).To(Equal(1))
+				_, id := fakeRancherClient.TokenArgsForCall(0)
+				Expect(id).To(Equal("kubeconfig-u-1"))
+			})
+		})
+
+		When("validating the cached token fails", func() {
+			BeforeEach(func() {
+				fakeRancherClient.TokenReturns(rancher.KubeconfigToken{}, errors.New("error getting token"))
+			})
+
+			It("serves the cached token", func() {
+				Expect(getToken().Token).To(Equal("rancher-token-1"))
+				Expect(getToken().Token).To(Equal("rancher-token-1"))
+				Expect(fakeRancherClient.NewTokenCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the cached token was revoked in rancher", func() {
+			BeforeEach(func() {
+				fakeRancherClient.TokenReturns(rancher.KubeconfigToken{}, fmt.Errorf("%w: kubeconfig-u-1", rancher.ErrTokenNotFound))
+			})
+
+			It("replaces it", func() {
+				Expect(getToken().Token).To(Equal("rancher-token-1"))
+				Expect(getToken().Token).To(Equal("rancher-token-2"))
+				Expect(fakeRancherClient.DeleteTokenCallCount()).To(Equal(1))
+				_, id := fakeRancherClient.DeleteTokenArgsForCall(0)
+				Expect(id).To(Equal("kubeconfig-u-1"))
+			})
+		})
+
+		When("rancher returns the replaced token again", func() {
+			BeforeEach(func() {
+				fakeRancherClient.NewTokenStub = nil
+				fakeRancherClient.NewTokenReturns(rancher.KubeconfigToken{
+					ID:    "kubeconfig-u-1",
+					Token: "rancher-token-1",
+				}, nil)
+				fakeRancherClient.TokenReturns(rancher.KubeconfigToken{Enabled: false}, nil)
+			})
+
+			It("does not revoke the token it serves", func() {
+				Expect(getToken().Token).To(Equal("rancher-token-1"))
+				Expect(getToken().Token).To(Equal("rancher-token-1"))
+				Expect(fakeRancherClient.NewTokenCallCount()).To(Equal(2))
+				Expect(fakeRancherClient.DeleteTokenCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("#GetAWSToken", func() {
+		BeforeEach(func() {
+			fakeAWSClient = &awsfakes.FakeClient{}
+			fakeAWSClient.NewTokenReturns(validAWSToken, nil)
+
+			// Create new gin instead of using gin.Default().
+			// This disables request logging which we don't want for tests.
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeAWS, aws.NewTokenProvider(fakeAWSClient))))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+			uri = svr.URL + "/tokens?provider=aws"
+			body = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			svr.Close()
+			res.Body.Close()
+		})
+
+		JustBeforeEach(func() {
+			req, _ = http.NewRequest(http.MethodGet, uri, nil)
+			res, err = http.DefaultClient.Do(req)
+		})
+
+		When("aws is not a configured provider", func() {
+			BeforeEach(func() {
+				r := gin.New()
+				r.Use(gin.Recovery())
+				r.GET("/tokens", arcadehttp.GetToken)
+
+				svr = httptest.NewServer(r)
+				uri = svr.URL + "/tokens?provider=aws"
+				body = &bytes.Buffer{}
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("token provider not configured: aws"))
+			})
+		})
+
+		When("getting a new token from aws fails", func() {
+			BeforeEach(func() {
+				fakeAWSClient.NewTokenReturns(aws.Token{}, errors.New("error getting token from aws"))
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("error getting token from aws"))
+			})
+		})
+
+		When("token is expired", func() {
+			BeforeEach(func() {
+				// Call first time to get the expired token.
+				fakeAWSClient.NewTokenReturns(expiredAWSToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeAWSClient.NewTokenReturns(validAWSToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Third call won't reach the client because the cached token is valid.
+				fakeAWSClient.NewTokenReturns(fakeAWSToken, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-aws-token"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-aws-token"))
+			})
+		})
+	})
+
+	Describe("#GetAzureToken", func() {
+		BeforeEach(func() {
+			fakeAzureClient = &azurefakes.FakeClient{}
+			fakeAzureClient.NewTokenReturns(validAzureToken, nil)
+
+			// Create new gin instead of using gin.Default().
+			// This disables request logging which we don't want for tests.
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeAzure, azure.NewTokenProvider(fakeAzureClient))))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+			uri = svr.URL + "/tokens?provider=azure"
+			body = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			svr.Close()
+			res.Body.Close()
+		})
+
+		JustBeforeEach(func() {
+			req, _ = http.NewRequest(http.MethodGet, uri, nil)
+			res, err = http.DefaultClient.Do(req)
+		})
+
+		When("azure is not a configured provider", func() {
+			BeforeEach(func() {
+				r := gin.New()
+				r.Use(gin.Recovery())
+				r.GET("/tokens", arcadehttp.GetToken)
+
+				svr = httptest.NewServer(r)
+				uri = svr.URL + "/tokens?provider=azure"
+				body = &bytes.Buffer{}
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("token provider not configured: azure"))
+			})
+		})
+
+		When("getting a new token from azure fails", func() {
+			BeforeEach(func() {
+				fakeAzureClient.NewTokenReturns(azure.Token{}, errors.New("error getting token from azure"))
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("error getting token from azure"))
+			})
+		})
+
+		When("token expires within the expiry skew", func() {
+			BeforeEach(func() {
+				// Call first time to get the expiring token.
+				fakeAzureClient.NewTokenReturns(expiringAzureToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeAzureClient.NewTokenReturns(validAzureToken, nil)
+			})
+
+			It("is not served from the cache", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-azure-token"))
+				Expect(fakeAzureClient.NewTokenCallCount()).To(Equal(2))
+			})
+		})
+
+		When("token is expired", func() {
+			BeforeEach(func() {
+				// Call first time to get the expired token.
+				fakeAzureClient.NewTokenReturns(expiredAzureToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeAzureClient.NewTokenReturns(validAzureToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Third call won't reach the client because the cached token is valid.
+				fakeAzureClient.NewTokenReturns(fakeAzureToken, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-azure-token"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-azure-token"))
+			})
+		})
+	})
+
+	Describe("#GetKubernetesToken", func() {
+		BeforeEach(func() {
+			fakeKubernetesClient = &kubernetesfakes.FakeClient{}
+			fakeKubernetesClient.NewTokenReturns(validTokenRequest, nil)
+
+			// Create new gin instead of using gin.Default().
+			// This disables request logging which we don't want for tests.
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeKubernetes, kubernetes.NewTokenProvider(fakeKubernetesClient))))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+			uri = svr.URL + "/tokens?provider=kubernetes"
+			body = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			svr.Close()
+			res.Body.Close()
+		})
+
+		JustBeforeEach(func() {
+			req, _ = http.NewRequest(http.MethodGet, uri, nil)
+			res, err = http.DefaultClient.Do(req)
+		})
+
+		When("kubernetes is not a configured provider", func() {
+			BeforeEach(func() {
+				r := gin.New()
+				r.Use(gin.Recovery())
+				r.GET("/tokens", arcadehttp.GetToken)
+
+				svr = httptest.NewServer(r)
+				uri = svr.URL + "/tokens?provider=kubernetes"
+				body = &bytes.Buffer{}
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("token provider not configured: kubernetes"))
+			})
+		})
+
+		When("getting a new token from kubernetes fails", func() {
+			BeforeEach(func() {
+				fakeKubernetesClient.NewTokenReturns(kubernetes.TokenRequest{}, errors.New("error getting token from kubernetes"))
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("error getting token from kubernetes"))
+			})
+		})
+
+		When("token is about to expire", func() {
+			BeforeEach(func() {
+				// Call first time to get the expiring token.
+				fakeKubernetesClient.NewTokenReturns(expiringTokenRequest, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeKubernetesClient.NewTokenReturns(validTokenRequest, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Third call won't reach the client because the cached token is valid.
+				fakeKubernetesClient.NewTokenReturns(fakeTokenRequest, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-kubernetes-token"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-kubernetes-token"))
+			})
+		})
+	})
+
+	Describe("#GetVaultToken", func() {
+		var expiredVaultCredentials, validVaultCredentials vault.Credentials
+
+		BeforeEach(func() {
+			expiredVaultCredentials = vault.Credentials{
+				LeaseID:   "kubernetes/creds/arcade/expired",
+				ExpiresAt: time.Now().In(time.UTC).Add(-24 * time.Hour),
+			}
+			expiredVaultCredentials.Data.ServiceAccountToken = "expired-vault-token"
+			validVaultCredentials = vault.Credentials{
+				LeaseID:   "kubernetes/creds/arcade/valid",
+				ExpiresAt: time.Now().In(time.UTC).Add(1 * time.Hour),
+			}
+			validVaultCredentials.Data.ServiceAccountToken = "valid-vault-token"
+
+			fakeVaultClient = &vaultfakes.FakeClient{}
+			fakeVaultClient.NewTokenReturns(validVaultCredentials, nil)
+
+			// Create new gin instead of using gin.Default().
+			// This disables request logging which we don't want for tests.
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeVault, vault.NewTokenProvider(fakeVaultClient))))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+			uri = svr.URL + "/tokens?provider=vault"
+			body = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			svr.Close()
+			res.Body.Close()
+		})
+
+		JustBeforeEach(func() {
+			req, _ = http.NewRequest(http.MethodGet, uri, nil)
+			res, err = http.DefaultClient.Do(req)
+		})
+
+		When("vault is not a configured provider", func() {
+			BeforeEach(func() {
+				r := gin.New()
+				r.Use(gin.Recovery())
+				r.GET("/tokens", arcadehttp.GetToken)
+
+				svr = httptest.NewServer(r)
+				uri = svr.URL + "/tokens?provider=vault"
+				body = &bytes.Buffer{}
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("token provider not configured: vault"))
+			})
+		})
+
+		When("getting a new token from vault fails", func() {
+			BeforeEach(func() {
+				fakeVaultClient.NewTokenReturns(vault.Credentials{}, errors.New("error getting token from vault"))
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("error getting token from vault"))
+			})
+		})
+
+		When("token is expired", func() {
+			BeforeEach(func() {
+				// Call first time to get the expired token.
+				fakeVaultClient.NewTokenReturns(expiredVaultCredentials, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeVaultClient.NewTokenReturns(validVaultCredentials, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Third call won't reach the client because the cached token is valid.
+				fakeVaultClient.NewTokenReturns(vault.Credentials{}, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-vault-token"))
+				Expect(fakeVaultClient.RevokeLeaseCallCount()).To(Equal(1))
+				_, leaseID := fakeVaultClient.RevokeLeaseArgsForCall(0)
+				Expect(leaseID).To(Equal("kubernetes/creds/arcade/expired"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-vault-token"))
+			})
+		})
+	})
+
+	Describe("#GetOIDCToken", func() {
+		BeforeEach(func() {
+			fakeOIDCClient = &oidcfakes.FakeClient{}
+			fakeOIDCClient.NewTokenReturns(validOIDCToken, nil)
+
+			// Create new gin instead of using gin.Default().
+			// This disables request logging which we don't want for tests.
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeOIDC, oidc.NewTokenProvider(fakeOIDCClient))))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+			uri = svr.URL + "/tokens?provider=oidc"
+			body = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			svr.Close()
+			res.Body.Close()
+		})
+
+		JustBeforeEach(func() {
+			req, _ = http.NewRequest(http.MethodGet, uri, nil)
+			res, err = http.DefaultClient.Do(req)
+		})
+
+		When("oidc is not a configured provider", func() {
+			BeforeEach(func() {
+				r := gin.New()
+				r.Use(gin.Recovery())
+				r.GET("/tokens", arcadehttp.GetToken)
+
+				svr = httptest.NewServer(r)
+				uri = svr.URL + "/tokens?provider=oidc"
+				body = &bytes.Buffer{}
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("token provider not configured: oidc"))
+			})
+		})
+
+		When("getting a new token from oidc fails", func() {
+			BeforeEach(func() {
+				fakeOIDCClient.NewTokenReturns(oidc.Token{}, errors.New("error getting token from oidc"))
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("error getting token from oidc"))
+			})
+		})
+
+		When("token is expired", func() {
+			BeforeEach(func() {
+				// Call first time to get the expired token.
+				fakeOIDCClient.NewTokenReturns(expiredOIDCToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeOIDCClient.NewTokenReturns(validOIDCToken, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Third call won't reach the client because the cached token is valid.
+				fakeOIDCClient.NewTokenReturns(fakeOIDCToken, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-oidc-token"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-oidc-token"))
+			})
+		})
+	})
+
+	Describe("#GetExecToken", func() {
+		BeforeEach(func() {
+			fakeExecClient = &execfakes.FakeClient{}
+			fakeExecClient.NewTokenReturns(validExecCredential, nil)
+
+			// Create new gin instead of using gin.Default().
+			// This disables request logging which we don't want for tests.
+			r := gin.New()
+			r.Use(gin.Recovery())
+			r.Use(middleware.SetProviderRegistry(newRegistry(provider.TypeExec, exec.NewTokenProvider(fakeExecClient))))
+			r.GET("/tokens", arcadehttp.GetToken)
+
+			svr = httptest.NewServer(r)
+			uri = svr.URL + "/tokens?provider=exec"
+			body = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			svr.Close()
+			res.Body.Close()
+		})
+
+		JustBeforeEach(func() {
+			req, _ = http.NewRequest(http.MethodGet, uri, nil)
+			res, err = http.DefaultClient.Do(req)
+		})
+
+		When("exec is not a configured provider", func() {
+			BeforeEach(func() {
+				r := gin.New()
+				r.Use(gin.Recovery())
+				r.GET("/tokens", arcadehttp.GetToken)
+
+				svr = httptest.NewServer(r)
+				uri = svr.URL + "/tokens?provider=exec"
+				body = &bytes.Buffer{}
+			})
+
+			It("returns a bad request error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("token provider not configured: exec"))
+			})
+		})
+
+		When("getting a new token from exec fails", func() {
+			BeforeEach(func() {
+				fakeExecClient.NewTokenReturns(exec.ExecCredential{}, errors.New("error getting token from exec"))
+			})
+
+			It("returns an internal server error", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusInternalServerError))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Error).To(Equal("error getting token from exec"))
+			})
+		})
+
+		When("token is expired", func() {
+			BeforeEach(func() {
+				// Call first time to get the expired token.
+				fakeExecClient.NewTokenReturns(expiredExecCredential, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Call second time to get valid token.
+				fakeExecClient.NewTokenReturns(validExecCredential, nil)
+				req, _ = http.NewRequest(http.MethodGet, uri, nil)
+				res, err = http.DefaultClient.Do(req)
+				// Third call won't reach the client because the cached token is valid.
+				fakeExecClient.NewTokenReturns(fakeExecCredential, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-exec-token"))
+			})
+		})
+
+		When("it succeeds", func() {
+			It("succeeds", func() {
+				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				b, _ := ioutil.ReadAll(res.Body)
+				_ = json.Unmarshal(b, &tokens)
+				Expect(tokens.Token).To(Equal("valid-exec-token"))
+			})
+		})
+	})
+})
+
+// newRegistry returns a registry with a single provider instance named
+// after its type.
+func newRegistry(typ string, tp provider.TokenProvider) *provider.Registry {
+	r := provider.NewRegistry()
+	Expect(r.Register(typ, typ, tp)).To(Succeed())
+
+	return r
+}
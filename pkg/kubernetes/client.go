@@ -0,0 +1,146 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultURL is the in-cluster address of the API server.
+	DefaultURL = "https://kubernetes.default.svc"
+	// DefaultBearerTokenFile is the in-cluster service account token
+	// used to authenticate to the API server.
+	DefaultBearerTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// DefaultExpirationSeconds is the requested lifetime of a token.
+	DefaultExpirationSeconds = 3600
+)
+
+var (
+	errNotFoundFormat = "error getting token: %s"
+	tokenRequestPath  = "/api/v1/namespaces/%s/serviceaccounts/%s/token"
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	NewToken(context.Context) (TokenRequest, error)
+	WithURL(string)
+	WithBearerTokenFile(string)
+	WithNamespace(string)
+	WithServiceAccount(string)
+	WithAudience(string)
+	WithExpirationSeconds(int64)
+	WithTransport(*http.Transport)
+}
+
+func NewClient() Client {
+	return &client{
+		url:               DefaultURL,
+		bearerTokenFile:   DefaultBearerTokenFile,
+		expirationSeconds: DefaultExpirationSeconds,
+		c:                 &http.Client{},
+	}
+}
+
+type client struct {
+	url               string
+	bearerTokenFile   string
+	namespace         string
+	serviceAccount    string
+	audience          string
+	expirationSeconds int64
+	c                 *http.Client
+}
+
+func (c *client) WithURL(url string) {
+	c.url = strings.TrimSuffix(url, "/")
+}
+
+func (c *client) WithBearerTokenFile(bearerTokenFile string) {
+	c.bearerTokenFile = bearerTokenFile
+}
+
+func (c *client) WithNamespace(namespace string) {
+	c.namespace = namespace
+}
+
+func (c *client) WithServiceAccount(serviceAccount string) {
+	c.serviceAccount = serviceAccount
+}
+
+func (c *client) WithAudience(audience string) {
+	c.audience = audience
+}
+
+func (c *client) WithExpirationSeconds(expirationSeconds int64) {
+	c.expirationSeconds = expirationSeconds
+}
+
+func (c *client) WithTransport(transport *http.Transport) {
+	c.c.Transport = transport
+}
+
+// NewToken creates a bound token for the configured service account
+// using the TokenRequest subresource.
+func (c *client) NewToken(ctx context.Context) (TokenRequest, error) {
+	tr := TokenRequest{}
+
+	data := NewTokenRequest{
+		APIVersion: "authentication.k8s.io/v1",
+		Kind:       "TokenRequest",
+		Spec: TokenRequestSpec{
+			ExpirationSeconds: c.expirationSeconds,
+		},
+	}
+
+	if c.audience != "" {
+		data.Spec.Audiences = []string{c.audience}
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return tr, err
+	}
+
+	// Read the bearer token on every request since it is rotated by the kubelet.
+	bearerToken, err := ioutil.ReadFile(c.bearerTokenFile)
+	if err != nil {
+		return tr, err
+	}
+
+	u := c.url + fmt.Sprintf(tokenRequestPath, c.namespace, c.serviceAccount)
+
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewBuffer(b))
+	if err != nil {
+		return tr, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Authorization", "Bearer "+strings.TrimSpace(string(bearerToken)))
+
+	res, err := c.c.Do(req)
+	if err != nil {
+		return tr, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		return tr, fmt.Errorf(errNotFoundFormat, res.Status)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&tr)
+	if err != nil {
+		return tr, err
+	}
+
+	return tr, nil
+}
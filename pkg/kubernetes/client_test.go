@@ -0,0 +1,161 @@
+package kubernetes_test
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/kubernetes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server *ghttp.Server
+		client Client
+		dir    string
+		tr     TokenRequest
+		err    error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		dir, _ = ioutil.TempDir("", "arcade-kubernetes")
+		bearerTokenFile := filepath.Join(dir, "token")
+		_ = ioutil.WriteFile(bearerTokenFile, []byte("test-bearer-token\n"), 0600)
+		client = NewClient()
+		client.WithURL(server.URL())
+		client.WithBearerTokenFile(bearerTokenFile)
+		client.WithNamespace("spinnaker")
+		client.WithServiceAccount("spinnaker")
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			tr, err = client.NewToken(context.Background())
+		})
+
+		When("the uri is invalid", func() {
+			BeforeEach(func() {
+				client.WithURL(":haha")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the bearer token file does not exist", func() {
+			BeforeEach(func() {
+				client.WithBearerTokenFile(filepath.Join(dir, "missing"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the server is not reachable", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the response is not 201", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusForbidden, nil),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: 403 Forbidden"))
+			})
+		})
+
+		When("the response is invalid", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusCreated, `{;'iuiuiu`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("invalid character ';' looking for beginning of object key string"))
+			})
+		})
+
+		When("the audience and expiration are set", func() {
+			BeforeEach(func() {
+				client.WithAudience("https://kubernetes.default.svc")
+				client.WithExpirationSeconds(600)
+				json := `{"apiVersion":"authentication.k8s.io/v1","kind":"TokenRequest","spec":{"audiences":["https://kubernetes.default.svc"],"expirationSeconds":600}}`
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/api/v1/namespaces/spinnaker/serviceaccounts/spinnaker/token"),
+					ghttp.VerifyJSON(json),
+					ghttp.RespondWith(http.StatusCreated, payloadTokenRequest),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(tr.Status.Token).To(HavePrefix("eyJhbGciOiJSUzI1NiIsImtpZCI6ImZha2UifQ."))
+			})
+		})
+
+		When("the transport is set", func() {
+			BeforeEach(func() {
+				t := &http.Transport{
+					TLSClientConfig: &tls.Config{},
+				}
+				client.WithTransport(t)
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/api/v1/namespaces/spinnaker/serviceaccounts/spinnaker/token"),
+					ghttp.RespondWith(http.StatusCreated, payloadTokenRequest),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(tr.Status.Token).To(HavePrefix("eyJhbGciOiJSUzI1NiIsImtpZCI6ImZha2UifQ."))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				json := `{"apiVersion":"authentication.k8s.io/v1","kind":"TokenRequest","spec":{"expirationSeconds":3600}}`
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/api/v1/namespaces/spinnaker/serviceaccounts/spinnaker/token"),
+					ghttp.VerifyJSON(json),
+					ghttp.VerifyHeaderKV("accept", "application/json"),
+					ghttp.VerifyHeaderKV("authorization", "Bearer test-bearer-token"),
+					ghttp.RespondWith(http.StatusCreated, payloadTokenRequest),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(tr.Status.Token).To(HavePrefix("eyJhbGciOiJSUzI1NiIsImtpZCI6ImZha2UifQ."))
+				Expect(tr.Status.ExpirationTimestamp).To(Equal(time.Date(2021, 3, 25, 11, 38, 18, 0, time.UTC)))
+			})
+		})
+	})
+})
@@ -0,0 +1,391 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package kubernetesfakes
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/homedepot/arcade/pkg/kubernetes"
+)
+
+type FakeClient struct {
+	NewTokenStub        func(context.Context) (kubernetes.TokenRequest, error)
+	newTokenMutex       sync.RWMutex
+	newTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	newTokenReturns struct {
+		result1 kubernetes.TokenRequest
+		result2 error
+	}
+	newTokenReturnsOnCall map[int]struct {
+		result1 kubernetes.TokenRequest
+		result2 error
+	}
+	WithAudienceStub        func(string)
+	withAudienceMutex       sync.RWMutex
+	withAudienceArgsForCall []struct {
+		arg1 string
+	}
+	WithBearerTokenFileStub        func(string)
+	withBearerTokenFileMutex       sync.RWMutex
+	withBearerTokenFileArgsForCall []struct {
+		arg1 string
+	}
+	WithExpirationSecondsStub        func(int64)
+	withExpirationSecondsMutex       sync.RWMutex
+	withExpirationSecondsArgsForCall []struct {
+		arg1 int64
+	}
+	WithNamespaceStub        func(string)
+	withNamespaceMutex       sync.RWMutex
+	withNamespaceArgsForCall []struct {
+		arg1 string
+	}
+	WithServiceAccountStub        func(string)
+	withServiceAccountMutex       sync.RWMutex
+	withServiceAccountArgsForCall []struct {
+		arg1 string
+	}
+	WithTransportStub        func(*http.Transport)
+	withTransportMutex       sync.RWMutex
+	withTransportArgsForCall []struct {
+		arg1 *http.Transport
+	}
+	WithURLStub        func(string)
+	withURLMutex       sync.RWMutex
+	withURLArgsForCall []struct {
+		arg1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) NewToken(arg1 context.Context) (kubernetes.TokenRequest, error) {
+	fake.newTokenMutex.Lock()
+	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
+	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
+	fake.recordInvocation("NewToken", []interface{}{arg1})
+	fake.newTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewTokenCallCount() int {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	return len(fake.newTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewTokenCalls(stub func(context.Context) (kubernetes.TokenRequest, error)) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = stub
+}
+
+func (fake *FakeClient) NewTokenArgsForCall(i int) context.Context {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	argsForCall := fake.newTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) NewTokenReturns(result1 kubernetes.TokenRequest, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	fake.newTokenReturns = struct {
+		result1 kubernetes.TokenRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 kubernetes.TokenRequest, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	if fake.newTokenReturnsOnCall == nil {
+		fake.newTokenReturnsOnCall = make(map[int]struct {
+			result1 kubernetes.TokenRequest
+			result2 error
+		})
+	}
+	fake.newTokenReturnsOnCall[i] = struct {
+		result1 kubernetes.TokenRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WithAudience(arg1 string) {
+	fake.withAudienceMutex.Lock()
+	fake.withAudienceArgsForCall = append(fake.withAudienceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAudienceStub
+	fake.recordInvocation("WithAudience", []interface{}{arg1})
+	fake.withAudienceMutex.Unlock()
+	if stub != nil {
+		fake.WithAudienceStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAudienceCallCount() int {
+	fake.withAudienceMutex.RLock()
+	defer fake.withAudienceMutex.RUnlock()
+	return len(fake.withAudienceArgsForCall)
+}
+
+func (fake *FakeClient) WithAudienceCalls(stub func(string)) {
+	fake.withAudienceMutex.Lock()
+	defer fake.withAudienceMutex.Unlock()
+	fake.WithAudienceStub = stub
+}
+
+func (fake *FakeClient) WithAudienceArgsForCall(i int) string {
+	fake.withAudienceMutex.RLock()
+	defer fake.withAudienceMutex.RUnlock()
+	argsForCall := fake.withAudienceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithBearerTokenFile(arg1 string) {
+	fake.withBearerTokenFileMutex.Lock()
+	fake.withBearerTokenFileArgsForCall = append(fake.withBearerTokenFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithBearerTokenFileStub
+	fake.recordInvocation("WithBearerTokenFile", []interface{}{arg1})
+	fake.withBearerTokenFileMutex.Unlock()
+	if stub != nil {
+		fake.WithBearerTokenFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithBearerTokenFileCallCount() int {
+	fake.withBearerTokenFileMutex.RLock()
+	defer fake.withBearerTokenFileMutex.RUnlock()
+	return len(fake.withBearerTokenFileArgsForCall)
+}
+
+func (fake *FakeClient) WithBearerTokenFileCalls(stub func(string)) {
+	fake.withBearerTokenFileMutex.Lock()
+	defer fake.withBearerTokenFileMutex.Unlock()
+	fake.WithBearerTokenFileStub = stub
+}
+
+func (fake *FakeClient) WithBearerTokenFileArgsForCall(i int) string {
+	fake.withBearerTokenFileMutex.RLock()
+	defer fake.withBearerTokenFileMutex.RUnlock()
+	argsForCall := fake.withBearerTokenFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithExpirationSeconds(arg1 int64) {
+	fake.withExpirationSecondsMutex.Lock()
+	fake.withExpirationSecondsArgsForCall = append(fake.withExpirationSecondsArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	stub := fake.WithExpirationSecondsStub
+	fake.recordInvocation("WithExpirationSeconds", []interface{}{arg1})
+	fake.withExpirationSecondsMutex.Unlock()
+	if stub != nil {
+		fake.WithExpirationSecondsStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithExpirationSecondsCallCount() int {
+	fake.withExpirationSecondsMutex.RLock()
+	defer fake.withExpirationSecondsMutex.RUnlock()
+	return len(fake.withExpirationSecondsArgsForCall)
+}
+
+func (fake *FakeClient) WithExpirationSecondsCalls(stub func(int64)) {
+	fake.withExpirationSecondsMutex.Lock()
+	defer fake.withExpirationSecondsMutex.Unlock()
+	fake.WithExpirationSecondsStub = stub
+}
+
+func (fake *FakeClient) WithExpirationSecondsArgsForCall(i int) int64 {
+	fake.withExpirationSecondsMutex.RLock()
+	defer fake.withExpirationSecondsMutex.RUnlock()
+	argsForCall := fake.withExpirationSecondsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithNamespace(arg1 string) {
+	fake.withNamespaceMutex.Lock()
+	fake.withNamespaceArgsForCall = append(fake.withNamespaceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithNamespaceStub
+	fake.recordInvocation("WithNamespace", []interface{}{arg1})
+	fake.withNamespaceMutex.Unlock()
+	if stub != nil {
+		fake.WithNamespaceStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithNamespaceCallCount() int {
+	fake.withNamespaceMutex.RLock()
+	defer fake.withNamespaceMutex.RUnlock()
+	return len(fake.withNamespaceArgsForCall)
+}
+
+func (fake *FakeClient) WithNamespaceCalls(stub func(string)) {
+	fake.withNamespaceMutex.Lock()
+	defer fake.withNamespaceMutex.Unlock()
+	fake.WithNamespaceStub = stub
+}
+
+func (fake *FakeClient) WithNamespaceArgsForCall(i int) string {
+	fake.withNamespaceMutex.RLock()
+	defer fake.withNamespaceMutex.RUnlock()
+	argsForCall := fake.withNamespaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithServiceAccount(arg1 string) {
+	fake.withServiceAccountMutex.Lock()
+	fake.withServiceAccountArgsForCall = append(fake.withServiceAccountArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithServiceAccountStub
+	fake.recordInvocation("WithServiceAccount", []interface{}{arg1})
+	fake.withServiceAccountMutex.Unlock()
+	if stub != nil {
+		fake.WithServiceAccountStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithServiceAccountCallCount() int {
+	fake.withServiceAccountMutex.RLock()
+	defer fake.withServiceAccountMutex.RUnlock()
+	return len(fake.withServiceAccountArgsForCall)
+}
+
+func (fake *FakeClient) WithServiceAccountCalls(stub func(string)) {
+	fake.withServiceAccountMutex.Lock()
+	defer fake.withServiceAccountMutex.Unlock()
+	fake.WithServiceAccountStub = stub
+}
+
+func (fake *FakeClient) WithServiceAccountArgsForCall(i int) string {
+	fake.withServiceAccountMutex.RLock()
+	defer fake.withServiceAccountMutex.RUnlock()
+	argsForCall := fake.withServiceAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithTransport(arg1 *http.Transport) {
+	fake.withTransportMutex.Lock()
+	fake.withTransportArgsForCall = append(fake.withTransportArgsForCall, struct {
+		arg1 *http.Transport
+	}{arg1})
+	stub := fake.WithTransportStub
+	fake.recordInvocation("WithTransport", []interface{}{arg1})
+	fake.withTransportMutex.Unlock()
+	if stub != nil {
+		fake.WithTransportStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTransportCallCount() int {
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	return len(fake.withTransportArgsForCall)
+}
+
+func (fake *FakeClient) WithTransportCalls(stub func(*http.Transport)) {
+	fake.withTransportMutex.Lock()
+	defer fake.withTransportMutex.Unlock()
+	fake.WithTransportStub = stub
+}
+
+func (fake *FakeClient) WithTransportArgsForCall(i int) *http.Transport {
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	argsForCall := fake.withTransportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithURL(arg1 string) {
+	fake.withURLMutex.Lock()
+	fake.withURLArgsForCall = append(fake.withURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithURLStub
+	fake.recordInvocation("WithURL", []interface{}{arg1})
+	fake.withURLMutex.Unlock()
+	if stub != nil {
+		fake.WithURLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithURLCallCount() int {
+	fake.withURLMutex.RLock()
+	defer fake.withURLMutex.RUnlock()
+	return len(fake.withURLArgsForCall)
+}
+
+func (fake *FakeClient) WithURLCalls(stub func(string)) {
+	fake.withURLMutex.Lock()
+	defer fake.withURLMutex.Unlock()
+	fake.WithURLStub = stub
+}
+
+func (fake *FakeClient) WithURLArgsForCall(i int) string {
+	fake.withURLMutex.RLock()
+	defer fake.withURLMutex.RUnlock()
+	argsForCall := fake.withURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	fake.withAudienceMutex.RLock()
+	defer fake.withAudienceMutex.RUnlock()
+	fake.withBearerTokenFileMutex.RLock()
+	defer fake.withBearerTokenFileMutex.RUnlock()
+	fake.withExpirationSecondsMutex.RLock()
+	defer fake.withExpirationSecondsMutex.RUnlock()
+	fake.withNamespaceMutex.RLock()
+	defer fake.withNamespaceMutex.RUnlock()
+	fake.withServiceAccountMutex.RLock()
+	defer fake.withServiceAccountMutex.RUnlock()
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	fake.withURLMutex.RLock()
+	defer fake.withURLMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ kubernetes.Client = new(FakeClient)
@@ -0,0 +1,22 @@
+package kubernetes_test
+
+const payloadTokenRequest = `{
+  "kind": "TokenRequest",
+  "apiVersion": "authentication.k8s.io/v1",
+  "metadata": {
+    "name": "spinnaker",
+    "namespace": "spinnaker",
+    "creationTimestamp": "2021-03-25T10:38:18Z"
+  },
+  "spec": {
+    "audiences": [
+      "https://kubernetes.default.svc"
+    ],
+    "expirationSeconds": 3600,
+    "boundObjectRef": null
+  },
+  "status": {
+    "token": "eyJhbGciOiJSUzI1NiIsImtpZCI6ImZha2UifQ.eyJzdWIiOiJzeXN0ZW06c2VydmljZWFjY291bnQ6c3Bpbm5ha2VyOnNwaW5uYWtlciJ9.ZmFrZQ",
+    "expirationTimestamp": "2021-03-25T11:38:18Z"
+  }
+}`
@@ -0,0 +1,29 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+// NewTokenProvider returns a provider.TokenProvider that creates bound
+// service account tokens with c.
+func NewTokenProvider(c Client) provider.TokenProvider {
+	return &tokenProvider{c: c}
+}
+
+type tokenProvider struct {
+	c Client
+}
+
+func (tp *tokenProvider) NewToken(ctx context.Context) (provider.Token, error) {
+	tr, err := tp.c.NewToken(ctx)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	return provider.Token{
+		Token:     tr.Status.Token,
+		ExpiresAt: tr.Status.ExpirationTimestamp,
+	}, nil
+}
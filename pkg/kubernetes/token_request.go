@@ -0,0 +1,27 @@
+package kubernetes
+
+import "time"
+
+type TokenRequest struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Spec       TokenRequestSpec   `json:"spec"`
+	Status     TokenRequestStatus `json:"status"`
+}
+
+// NewTokenRequest is the body POSTed to the TokenRequest subresource.
+type NewTokenRequest struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Spec       TokenRequestSpec `json:"spec"`
+}
+
+type TokenRequestSpec struct {
+	Audiences         []string `json:"audiences,omitempty"`
+	ExpirationSeconds int64    `json:"expirationSeconds,omitempty"`
+}
+
+type TokenRequestStatus struct {
+	Token               string    `json:"token"`
+	ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+}
@@ -1,34 +1,63 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/homedepot/arcade/pkg/google"
-	"github.com/homedepot/arcade/pkg/rancher"
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+const (
+	// APIKeyNameKey is the context key of the name of the API key a
+	// request was authenticated with.
+	APIKeyNameKey = "APIKeyName"
+	// DefaultAPIKeyName is the name of the API key set by ARCADE_API_KEY.
+	DefaultAPIKeyName = "default"
+	// AdminAPIKeyName is the name of the API key set by
+	// ARCADE_ADMIN_API_KEY, which is required to list and revoke tokens.
+	AdminAPIKeyName = "admin"
 )
 
 func NewApiKeyAuth(apiKey string) gin.HandlerFunc {
+	return NewApiKeysAuth(map[string]string{DefaultAPIKeyName: apiKey})
+}
+
+// NewApiKeysAuth accepts any of the given API keys, which are mapped by
+// name, and sets the name of the key on the context.
+func NewApiKeysAuth(apiKeys map[string]string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if c.Request.Header.Get("Api-Key") != apiKey {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "bad api key"})
-			return
+		key := c.Request.Header.Get("Api-Key")
+
+		for name, apiKey := range apiKeys {
+			if apiKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+				c.Set(APIKeyNameKey, name)
+				c.Next()
+
+				return
+			}
 		}
 
-		c.Next()
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "bad api key"})
 	}
 }
 
-func SetGoogleClient(g google.Client) gin.HandlerFunc {
+// RequireAPIKeyName only accepts requests authenticated with the API key
+// of the given name.
+func RequireAPIKeyName(name string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Set(google.Key, g)
+		if c.GetString(APIKeyNameKey) != name {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": name + " api key required"})
+			return
+		}
+
 		c.Next()
 	}
 }
 
-func SetRancherClient(r rancher.Client) gin.HandlerFunc {
+func SetProviderRegistry(r *provider.Registry) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Set(rancher.Key, r)
+		c.Set(provider.Key, r)
 		c.Next()
 	}
 }
@@ -0,0 +1,245 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	errDiscoveryFormat   = "error getting openid configuration: %s"
+	errIDTokenNotFound   = errors.New("error getting token: no id_token in token response")
+	wellKnownPath        = "/.well-known/openid-configuration"
+	defaultRefreshScopes = []string{"openid"}
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	NewToken(context.Context) (Token, error)
+	WithIssuerURL(string)
+	WithClientID(string)
+	WithClientSecret(string)
+	WithRefreshToken(string)
+	WithRefreshTokenFile(string)
+	WithTransport(*http.Transport)
+}
+
+func NewClient() Client {
+	return &client{
+		c: &http.Client{},
+	}
+}
+
+type client struct {
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	refreshToken string
+	// refreshTokenFile holds the refresh token if it is set. Refresh tokens
+	// rotated by the issuer are written back to it.
+	refreshTokenFile string
+	refreshTokenRead bool
+	tokenEndpoint    string
+	mux              sync.Mutex
+	c                *http.Client
+}
+
+func (c *client) WithIssuerURL(issuerURL string) {
+	c.issuerURL = strings.TrimSuffix(issuerURL, "/")
+	c.tokenEndpoint = ""
+}
+
+func (c *client) WithClientID(clientID string) {
+	c.clientID = clientID
+}
+
+func (c *client) WithClientSecret(clientSecret string) {
+	c.clientSecret = clientSecret
+}
+
+func (c *client) WithRefreshToken(refreshToken string) {
+	c.refreshToken = refreshToken
+}
+
+// WithRefreshTokenFile sets a file that holds the refresh token. It is read
+// on the first request, taking precedence over the refresh token, and
+// overwritten with rotated refresh tokens, so they survive restarts.
+func (c *client) WithRefreshTokenFile(refreshTokenFile string) {
+	c.refreshTokenFile = refreshTokenFile
+}
+
+func (c *client) WithTransport(transport *http.Transport) {
+	c.c.Transport = transport
+}
+
+// NewToken redeems the refresh token at the issuer's token endpoint and
+// returns the id_token from the response. Issuers that rotate refresh
+// tokens return a new one, which replaces the configured refresh token and
+// is written to the refresh token file if one is set.
+func (c *client) NewToken(ctx context.Context) (Token, error) {
+	t := Token{}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.refreshTokenFile != "" && !c.refreshTokenRead {
+		err := c.readRefreshToken()
+		if err != nil {
+			return t, err
+		}
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.c)
+
+	if c.tokenEndpoint == "" {
+		tokenEndpoint, err := c.discover(ctx)
+		if err != nil {
+			return t, err
+		}
+
+		c.tokenEndpoint = tokenEndpoint
+	}
+
+	config := oauth2.Config{
+		ClientID:     c.clientID,
+		ClientSecret: c.clientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL: c.tokenEndpoint,
+		},
+		Scopes: defaultRefreshScopes,
+	}
+
+	token, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: c.refreshToken}).Token()
+	if err != nil {
+		return t, err
+	}
+
+	if token.RefreshToken != "" && token.RefreshToken != c.refreshToken {
+		c.refreshToken = token.RefreshToken
+		c.persistRefreshToken()
+	}
+
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return t, errIDTokenNotFound
+	}
+
+	t.Token = idToken
+
+	t.ExpiresAt, err = expiresAt(idToken)
+	if err != nil {
+		return t, err
+	}
+
+	return t, nil
+}
+
+// readRefreshToken reads the refresh token file. The configured refresh
+// token is kept while the file does not exist, so it can seed the file.
+func (c *client) readRefreshToken() error {
+	b, err := ioutil.ReadFile(c.refreshTokenFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if refreshToken := strings.TrimSpace(string(b)); refreshToken != "" {
+		c.refreshToken = refreshToken
+	}
+
+	if c.refreshToken == "" {
+		return fmt.Errorf("no refresh token in %s", c.refreshTokenFile)
+	}
+
+	c.refreshTokenRead = true
+
+	return nil
+}
+
+// persistRefreshToken writes a rotated refresh token to the refresh token
+// file. Without one the token is only kept in memory, and the configured
+// refresh token, which the issuer may now treat as reused, is redeemed
+// again after a restart.
+func (c *client) persistRefreshToken() {
+	if c.refreshTokenFile == "" {
+		log.Printf("issuer %s rotated the refresh token, which is not persisted without a refresh token file", c.issuerURL)
+		return
+	}
+
+	err := writeFile(c.refreshTokenFile, []byte(c.refreshToken+"\n"))
+	if err != nil {
+		log.Printf("error writing the rotated refresh token to %s: %s", c.refreshTokenFile, err.Error())
+	}
+}
+
+// writeFile replaces the file at path with b, so it is never left partly
+// written.
+func writeFile(path string, b []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Chmod(0600)
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// discover looks up the token endpoint in the issuer's OpenID Provider
+// configuration.
+func (c *client) discover(ctx context.Context) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, c.issuerURL+wellKnownPath, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+
+	res, err := c.c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		return "", fmt.Errorf(errDiscoveryFormat, res.Status)
+	}
+
+	pc := providerConfiguration{}
+
+	err = json.NewDecoder(res.Body).Decode(&pc)
+	if err != nil {
+		return "", err
+	}
+
+	if pc.TokenEndpoint == "" {
+		return "", fmt.Errorf(errDiscoveryFormat, "no token_endpoint")
+	}
+
+	return pc.TokenEndpoint, nil
+}
@@ -0,0 +1,256 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/oidc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server  *ghttp.Server
+		client  Client
+		exp     time.Time
+		idToken string
+		t       Token
+		err     error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		exp = time.Now().Add(time.Hour).Truncate(time.Second).In(time.UTC)
+		idToken = newJWT(fmt.Sprintf(`{"iss":"%s","sub":"arcade","exp":%d}`, server.URL(), exp.Unix()))
+		client = NewClient()
+		client.WithIssuerURL(server.URL())
+		client.WithClientID("test-client")
+		client.WithClientSecret("test-secret")
+		client.WithRefreshToken("test-refresh-token")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			t, err = client.NewToken(context.Background())
+		})
+
+		When("the server is not reachable", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("discovery fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusNotFound, nil),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting openid configuration: 404 Not Found"))
+			})
+		})
+
+		When("the configuration has no token endpoint", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{"issuer":"https://issuer.example.com"}`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting openid configuration: no token_endpoint"))
+			})
+		})
+
+		When("the refresh token is rejected", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					discoveryHandler(server),
+					// The token endpoint is tried with both client authentication styles.
+					ghttp.RespondWith(http.StatusBadRequest, `{"error":"invalid_grant"}`),
+					ghttp.RespondWith(http.StatusBadRequest, `{"error":"invalid_grant"}`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the response has no id_token", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					discoveryHandler(server),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"access_token": "fake-access-token",
+						"token_type":   "Bearer",
+						"expires_in":   3600,
+					}),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: no id_token in token response"))
+			})
+		})
+
+		When("the id_token is malformed", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					discoveryHandler(server),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"access_token": "fake-access-token",
+						"id_token":     "not-a-jwt",
+						"token_type":   "Bearer",
+						"expires_in":   3600,
+					}),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: malformed id_token"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					discoveryHandler(server),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/token"),
+						ghttp.VerifyBasicAuth("test-client", "test-secret"),
+						ghttp.VerifyForm(map[string][]string{
+							"grant_type":    {"refresh_token"},
+							"refresh_token": {"test-refresh-token"},
+						}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+							"access_token":  "fake-access-token",
+							"id_token":      idToken,
+							"refresh_token": "rotated-refresh-token",
+							"token_type":    "Bearer",
+							"expires_in":    3600,
+						}),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal(idToken))
+				Expect(t.ExpiresAt).To(Equal(exp))
+			})
+
+			It("uses the rotated refresh token and cached token endpoint next time", func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/token"),
+					ghttp.VerifyForm(map[string][]string{
+						"refresh_token": {"rotated-refresh-token"},
+					}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"access_token": "fake-access-token",
+						"id_token":     idToken,
+						"token_type":   "Bearer",
+						"expires_in":   3600,
+					}),
+				))
+
+				_, err = client.NewToken(context.Background())
+				Expect(err).To(BeNil())
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+
+		When("the refresh token is read from a file", func() {
+			var (
+				dir              string
+				refreshTokenFile string
+			)
+
+			BeforeEach(func() {
+				dir, _ = ioutil.TempDir("", "arcade-oidc")
+				refreshTokenFile = filepath.Join(dir, "refresh-token")
+				_ = ioutil.WriteFile(refreshTokenFile, []byte("file-refresh-token\n"), 0600)
+				client.WithRefreshTokenFile(refreshTokenFile)
+				server.AppendHandlers(
+					discoveryHandler(server),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/token"),
+						ghttp.VerifyForm(map[string][]string{
+							"refresh_token": {"file-refresh-token"},
+						}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+							"access_token":  "fake-access-token",
+							"id_token":      idToken,
+							"refresh_token": "rotated-refresh-token",
+							"token_type":    "Bearer",
+							"expires_in":    3600,
+						}),
+					),
+				)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("writes the rotated refresh token back to the file", func() {
+				Expect(err).To(BeNil())
+				b, _ := ioutil.ReadFile(refreshTokenFile)
+				Expect(string(b)).To(Equal("rotated-refresh-token\n"))
+			})
+
+			When("the file does not exist yet", func() {
+				BeforeEach(func() {
+					os.Remove(refreshTokenFile)
+					client.WithRefreshToken("file-refresh-token")
+				})
+
+				It("seeds it with the rotated refresh token", func() {
+					Expect(err).To(BeNil())
+					b, _ := ioutil.ReadFile(refreshTokenFile)
+					Expect(string(b)).To(Equal("rotated-refresh-token\n"))
+				})
+			})
+		})
+	})
+})
+
+func discoveryHandler(server *ghttp.Server) http.HandlerFunc {
+	return ghttp.CombineHandlers(
+		ghttp.VerifyRequest(http.MethodGet, "/.well-known/openid-configuration"),
+		ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+			"issuer":         server.URL(),
+			"token_endpoint": server.URL() + "/token",
+		}),
+	)
+}
+
+func newJWT(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	return header + "." + payload + ".ZmFrZQ"
+}
@@ -0,0 +1,352 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package oidcfakes
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/homedepot/arcade/pkg/oidc"
+)
+
+type FakeClient struct {
+	NewTokenStub        func(context.Context) (oidc.Token, error)
+	newTokenMutex       sync.RWMutex
+	newTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	newTokenReturns struct {
+		result1 oidc.Token
+		result2 error
+	}
+	newTokenReturnsOnCall map[int]struct {
+		result1 oidc.Token
+		result2 error
+	}
+	WithClientIDStub        func(string)
+	withClientIDMutex       sync.RWMutex
+	withClientIDArgsForCall []struct {
+		arg1 string
+	}
+	WithClientSecretStub        func(string)
+	withClientSecretMutex       sync.RWMutex
+	withClientSecretArgsForCall []struct {
+		arg1 string
+	}
+	WithIssuerURLStub        func(string)
+	withIssuerURLMutex       sync.RWMutex
+	withIssuerURLArgsForCall []struct {
+		arg1 string
+	}
+	WithRefreshTokenStub        func(string)
+	withRefreshTokenMutex       sync.RWMutex
+	withRefreshTokenArgsForCall []struct {
+		arg1 string
+	}
+	WithRefreshTokenFileStub        func(string)
+	withRefreshTokenFileMutex       sync.RWMutex
+	withRefreshTokenFileArgsForCall []struct {
+		arg1 string
+	}
+	WithTransportStub        func(*http.Transport)
+	withTransportMutex       sync.RWMutex
+	withTransportArgsForCall []struct {
+		arg1 *http.Transport
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) NewToken(arg1 context.Context) (oidc.Token, error) {
+	fake.newTokenMutex.Lock()
+	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
+	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
+	fake.recordInvocation("NewToken", []interface{}{arg1})
+	fake.newTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewTokenCallCount() int {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	return len(fake.newTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewTokenCalls(stub func(context.Context) (oidc.Token, error)) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = stub
+}
+
+func (fake *FakeClient) NewTokenArgsForCall(i int) context.Context {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	argsForCall := fake.newTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) NewTokenReturns(result1 oidc.Token, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	fake.newTokenReturns = struct {
+		result1 oidc.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 oidc.Token, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	if fake.newTokenReturnsOnCall == nil {
+		fake.newTokenReturnsOnCall = make(map[int]struct {
+			result1 oidc.Token
+			result2 error
+		})
+	}
+	fake.newTokenReturnsOnCall[i] = struct {
+		result1 oidc.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WithClientID(arg1 string) {
+	fake.withClientIDMutex.Lock()
+	fake.withClientIDArgsForCall = append(fake.withClientIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithClientIDStub
+	fake.recordInvocation("WithClientID", []interface{}{arg1})
+	fake.withClientIDMutex.Unlock()
+	if stub != nil {
+		fake.WithClientIDStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithClientIDCallCount() int {
+	fake.withClientIDMutex.RLock()
+	defer fake.withClientIDMutex.RUnlock()
+	return len(fake.withClientIDArgsForCall)
+}
+
+func (fake *FakeClient) WithClientIDCalls(stub func(string)) {
+	fake.withClientIDMutex.Lock()
+	defer fake.withClientIDMutex.Unlock()
+	fake.WithClientIDStub = stub
+}
+
+func (fake *FakeClient) WithClientIDArgsForCall(i int) string {
+	fake.withClientIDMutex.RLock()
+	defer fake.withClientIDMutex.RUnlock()
+	argsForCall := fake.withClientIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithClientSecret(arg1 string) {
+	fake.withClientSecretMutex.Lock()
+	fake.withClientSecretArgsForCall = append(fake.withClientSecretArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithClientSecretStub
+	fake.recordInvocation("WithClientSecret", []interface{}{arg1})
+	fake.withClientSecretMutex.Unlock()
+	if stub != nil {
+		fake.WithClientSecretStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithClientSecretCallCount() int {
+	fake.withClientSecretMutex.RLock()
+	defer fake.withClientSecretMutex.RUnlock()
+	return len(fake.withClientSecretArgsForCall)
+}
+
+func (fake *FakeClient) WithClientSecretCalls(stub func(string)) {
+	fake.withClientSecretMutex.Lock()
+	defer fake.withClientSecretMutex.Unlock()
+	fake.WithClientSecretStub = stub
+}
+
+func (fake *FakeClient) WithClientSecretArgsForCall(i int) string {
+	fake.withClientSecretMutex.RLock()
+	defer fake.withClientSecretMutex.RUnlock()
+	argsForCall := fake.withClientSecretArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithIssuerURL(arg1 string) {
+	fake.withIssuerURLMutex.Lock()
+	fake.withIssuerURLArgsForCall = append(fake.withIssuerURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithIssuerURLStub
+	fake.recordInvocation("WithIssuerURL", []interface{}{arg1})
+	fake.withIssuerURLMutex.Unlock()
+	if stub != nil {
+		fake.WithIssuerURLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithIssuerURLCallCount() int {
+	fake.withIssuerURLMutex.RLock()
+	defer fake.withIssuerURLMutex.RUnlock()
+	return len(fake.withIssuerURLArgsForCall)
+}
+
+func (fake *FakeClient) WithIssuerURLCalls(stub func(string)) {
+	fake.withIssuerURLMutex.Lock()
+	defer fake.withIssuerURLMutex.Unlock()
+	fake.WithIssuerURLStub = stub
+}
+
+func (fake *FakeClient) WithIssuerURLArgsForCall(i int) string {
+	fake.withIssuerURLMutex.RLock()
+	defer fake.withIssuerURLMutex.RUnlock()
+	argsForCall := fake.withIssuerURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithRefreshToken(arg1 string) {
+	fake.withRefreshTokenMutex.Lock()
+	fake.withRefreshTokenArgsForCall = append(fake.withRefreshTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithRefreshTokenStub
+	fake.recordInvocation("WithRefreshToken", []interface{}{arg1})
+	fake.withRefreshTokenMutex.Unlock()
+	if stub != nil {
+		fake.WithRefreshTokenStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithRefreshTokenCallCount() int {
+	fake.withRefreshTokenMutex.RLock()
+	defer fake.withRefreshTokenMutex.RUnlock()
+	return len(fake.withRefreshTokenArgsForCall)
+}
+
+func (fake *FakeClient) WithRefreshTokenCalls(stub func(string)) {
+	fake.withRefreshTokenMutex.Lock()
+	defer fake.withRefreshTokenMutex.Unlock()
+	fake.WithRefreshTokenStub = stub
+}
+
+func (fake *FakeClient) WithRefreshTokenArgsForCall(i int) string {
+	fake.withRefreshTokenMutex.RLock()
+	defer fake.withRefreshTokenMutex.RUnlock()
+	argsForCall := fake.withRefreshTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithRefreshTokenFile(arg1 string) {
+	fake.withRefreshTokenFileMutex.Lock()
+	fake.withRefreshTokenFileArgsForCall = append(fake.withRefreshTokenFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithRefreshTokenFileStub
+	fake.recordInvocation("WithRefreshTokenFile", []interface{}{arg1})
+	fake.withRefreshTokenFileMutex.Unlock()
+	if stub != nil {
+		fake.WithRefreshTokenFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithRefreshTokenFileCallCount() int {
+	fake.withRefreshTokenFileMutex.RLock()
+	defer fake.withRefreshTokenFileMutex.RUnlock()
+	return len(fake.withRefreshTokenFileArgsForCall)
+}
+
+func (fake *FakeClient) WithRefreshTokenFileCalls(stub func(string)) {
+	fake.withRefreshTokenFileMutex.Lock()
+	defer fake.withRefreshTokenFileMutex.Unlock()
+	fake.WithRefreshTokenFileStub = stub
+}
+
+func (fake *FakeClient) WithRefreshTokenFileArgsForCall(i int) string {
+	fake.withRefreshTokenFileMutex.RLock()
+	defer fake.withRefreshTokenFileMutex.RUnlock()
+	argsForCall := fake.withRefreshTokenFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithTransport(arg1 *http.Transport) {
+	fake.withTransportMutex.Lock()
+	fake.withTransportArgsForCall = append(fake.withTransportArgsForCall, struct {
+		arg1 *http.Transport
+	}{arg1})
+	stub := fake.WithTransportStub
+	fake.recordInvocation("WithTransport", []interface{}{arg1})
+	fake.withTransportMutex.Unlock()
+	if stub != nil {
+		fake.WithTransportStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTransportCallCount() int {
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	return len(fake.withTransportArgsForCall)
+}
+
+func (fake *FakeClient) WithTransportCalls(stub func(*http.Transport)) {
+	fake.withTransportMutex.Lock()
+	defer fake.withTransportMutex.Unlock()
+	fake.WithTransportStub = stub
+}
+
+func (fake *FakeClient) WithTransportArgsForCall(i int) *http.Transport {
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	argsForCall := fake.withTransportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	fake.withClientIDMutex.RLock()
+	defer fake.withClientIDMutex.RUnlock()
+	fake.withClientSecretMutex.RLock()
+	defer fake.withClientSecretMutex.RUnlock()
+	fake.withIssuerURLMutex.RLock()
+	defer fake.withIssuerURLMutex.RUnlock()
+	fake.withRefreshTokenMutex.RLock()
+	defer fake.withRefreshTokenMutex.RUnlock()
+	fake.withRefreshTokenFileMutex.RLock()
+	defer fake.withRefreshTokenFileMutex.RUnlock()
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ oidc.Client = new(FakeClient)
@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	errMalformedIDToken = errors.New("error getting token: malformed id_token")
+)
+
+type Token struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// providerConfiguration is the subset of the OpenID Provider metadata
+// arcade needs.
+type providerConfiguration struct {
+	Issuer        string `json:"issuer"`
+	TokenEndpoint string `json:"token_endpoint"`
+}
+
+// expiresAt reads the exp claim of a JWT without verifying its signature.
+// The token comes straight from the issuer's token endpoint, so it is only
+// inspected to know how long it can be cached.
+func expiresAt(jwt string) (time.Time, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errMalformedIDToken
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, errMalformedIDToken
+	}
+
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+
+	err = json.Unmarshal(b, &claims)
+	if err != nil || claims.Exp == 0 {
+		return time.Time{}, errMalformedIDToken
+	}
+
+	return time.Unix(claims.Exp, 0).In(time.UTC), nil
+}
@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	Key = "ProviderRegistry"
+	// ParamAPIKey is the parameter a ParamsTokenProvider declares to get
+	// the name of the API key a request was authenticated with. It is
+	// never read from the query.
+	ParamAPIKey = "apiKey"
+	// DefaultName is the provider used when a request does not name one.
+	DefaultName = TypeGoogle
+
+	TypeGoogle     = "google"
+	TypeRancher    = "rancher"
+	TypeAWS        = "aws"
+	TypeAzure      = "azure"
+	TypeKubernetes = "kubernetes"
+	TypeVault      = "vault"
+	TypeOIDC       = "oidc"
+	TypeExec       = "exec"
+
+	// DefaultExpirySkew is how long before they expire cached tokens are
+	// replaced, so a token is never served just before it expires.
+	DefaultExpirySkew = 1 * time.Minute
+)
+
+var (
+	// ErrInvalidParams is wrapped by the errors a ParamsTokenProvider
+	// returns for parameters it does not accept.
+	ErrInvalidParams       = errors.New("invalid parameters")
+	errNameNotSet          = errors.New("provider name not set")
+	errTokenProviderNotSet = errors.New("token provider not set")
+	errDuplicateNameFormat = "duplicate provider name: %s"
+	errNotRegisteredFormat = "provider not registered: %s"
+	types                  = []string{
+		TypeGoogle,
+		TypeRancher,
+		TypeAWS,
+		TypeAzure,
+		TypeKubernetes,
+		TypeVault,
+		TypeOIDC,
+		TypeExec,
+	}
+)
+
+// Token is a token created by a TokenProvider.
+type Token struct {
+	Token string
+	// ExpiresAt is when the token should no longer be served from the
+	// cache. A zero ExpiresAt means the token is not cached.
+	ExpiresAt time.Time
+	// IssuedAt is when the upstream created the token. The time the token
+	// was created by the TokenProvider is used when it is zero.
+	IssuedAt time.Time
+	// ID is the upstream identifier of the token, for example a Rancher
+	// token ID or a Vault lease ID.
+	ID       string
+	Metadata map[string]string
+}
+
+//go:generate counterfeiter . TokenProvider
+
+// TokenProvider creates tokens. Every provider type implements it by
+// wrapping its own client.
+type TokenProvider interface {
+	NewToken(context.Context) (Token, error)
+}
+
+// ParamsTokenProvider is implemented by token providers whose tokens
+// depend on query parameters of the request, such as the service account
+// to impersonate. Tokens are cached per provider and parameter values.
+type ParamsTokenProvider interface {
+	TokenProvider
+	// Params returns the names of the query parameters the provider reads.
+	Params() []string
+	// NewTokenWithParams creates a token for the given parameters. Only
+	// the parameters returned by Params are set.
+	NewTokenWithParams(context.Context, url.Values) (Token, error)
+}
+
+// ParamsNormalizer is implemented by a ParamsTokenProvider whose
+// parameters can be given in more than one form for the same token, such
+// as scopes in any order. Tokens are cached by the normalized parameters.
+type ParamsNormalizer interface {
+	NormalizeParams(url.Values) url.Values
+}
+
+// Revoker is implemented by token providers that clean up tokens once
+// they have been replaced in the cache.
+type Revoker interface {
+	Revoke(context.Context, Token) error
+}
+
+// TokenLister is implemented by token providers that can list the tokens
+// they created upstream, so tokens that are no longer cached can be
+// revoked.
+type TokenLister interface {
+	Tokens(context.Context) ([]Token, error)
+}
+
+// TokenValidator is implemented by token providers whose tokens can be
+// revoked upstream before they expire. A cached token is validated again
+// once ValidationInterval has passed since it was last validated, and is
+// replaced if it is no longer valid. A zero interval disables validation.
+type TokenValidator interface {
+	ValidationInterval() time.Duration
+	Validate(context.Context, Token) (bool, error)
+}
+
+// ExpirySkewer is implemented by token providers whose tokens are replaced
+// a different time than DefaultExpirySkew before they expire.
+type ExpirySkewer interface {
+	ExpirySkew() time.Duration
+}
+
+// Closer is implemented by token providers that clean up when arcade
+// shuts down.
+type Closer interface {
+	Close(context.Context) error
+}
+
+// Cluster is a Kubernetes cluster the tokens of a provider are valid for.
+type Cluster struct {
+	Name string
+	// ID is the upstream identifier of a discovered cluster, for example a
+	// Rancher cluster ID.
+	ID     string
+	Server string
+	// CertificateAuthorityData is the PEM encoded CA of the cluster. The
+	// system roots are used when it is empty.
+	CertificateAuthorityData []byte
+	InsecureSkipTLSVerify    bool
+	// TokenParams are the query parameters that request a token scoped to
+	// the cluster from a ParamsTokenProvider, for example cluster=c-m7v2x.
+	TokenParams url.Values
+	Metadata    map[string]string
+}
+
+// ClusterDiscoverer is implemented by token providers that can look up
+// the clusters their tokens are valid for.
+type ClusterDiscoverer interface {
+	Clusters(context.Context) ([]Cluster, error)
+}
+
+// Instance is a named, configured TokenProvider.
+type Instance struct {
+	Name          string
+	Type          string
+	TokenProvider TokenProvider
+	// Clusters are the clusters configured for the instance. They take
+	// precedence over clusters found by a ClusterDiscoverer.
+	Clusters []Cluster
+
+	cachesMux sync.Mutex
+	caches    map[string]*TokenCache
+}
+
+// TokenCache holds the last token an instance returned for a set of
+// parameters. It must be locked while it is used.
+type TokenCache struct {
+	sync.Mutex
+	Token    Token
+	CachedAt time.Time
+	// ValidatedAt is when the token was last validated by a
+	// TokenValidator.
+	ValidatedAt time.Time
+}
+
+// TokenCache returns the token cache of the instance for the given encoded
+// parameters, which are empty for a TokenProvider without parameters.
+func (i *Instance) TokenCache(params string) *TokenCache {
+	i.cachesMux.Lock()
+	defer i.cachesMux.Unlock()
+
+	if i.caches == nil {
+		i.caches = map[string]*TokenCache{}
+	}
+
+	if _, ok := i.caches[params]; !ok {
+		i.caches[params] = &TokenCache{}
+	}
+
+	return i.caches[params]
+}
+
+// TokenCaches returns every token cache of the instance.
+func (i *Instance) TokenCaches() []*TokenCache {
+	i.cachesMux.Lock()
+	defer i.cachesMux.Unlock()
+
+	caches := make([]*TokenCache, 0, len(i.caches))
+	for _, tc := range i.caches {
+		caches = append(caches, tc)
+	}
+
+	return caches
+}
+
+// Registry holds the provider instances arcade serves tokens for.
+type Registry struct {
+	mux       sync.RWMutex
+	instances map[string]*Instance
+	// apiKeys maps the names of restricted API keys to the names of the
+	// instances they may use.
+	apiKeys map[string]map[string]struct{}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		instances: map[string]*Instance{},
+		apiKeys:   map[string]map[string]struct{}{},
+	}
+}
+
+// Register adds a provider instance. Names must be unique. The type is
+// informational, so in-house providers can use types arcade does not know.
+func (r *Registry) Register(name, typ string, tp TokenProvider) error {
+	if name == "" {
+		return errNameNotSet
+	}
+
+	if tp == nil {
+		return errTokenProviderNotSet
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if _, exists := r.instances[name]; exists {
+		return fmt.Errorf(errDuplicateNameFormat, name)
+	}
+
+	r.instances[name] = &Instance{
+		Name:          name,
+		Type:          typ,
+		TokenProvider: tp,
+	}
+
+	return nil
+}
+
+// RegisterClusters adds clusters to a registered provider instance.
+func (r *Registry) RegisterClusters(name string, clusters ...Cluster) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	instance, exists := r.instances[name]
+	if !exists {
+		return fmt.Errorf(errNotRegisteredFormat, name)
+	}
+
+	instance.Clusters = append(instance.Clusters, clusters...)
+
+	return nil
+}
+
+// RestrictAPIKey limits the API key with the given name to the instances
+// with the given names. API keys that are not restricted may use any
+// instance.
+func (r *Registry) RestrictAPIKey(apiKeyName string, names ...string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if _, ok := r.apiKeys[apiKeyName]; !ok {
+		r.apiKeys[apiKeyName] = map[string]struct{}{}
+	}
+
+	for _, name := range names {
+		r.apiKeys[apiKeyName][name] = struct{}{}
+	}
+}
+
+// Allows returns true if the API key with the given name may use the
+// instance with the given name.
+func (r *Registry) Allows(apiKeyName, name string) bool {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	names, restricted := r.apiKeys[apiKeyName]
+	if !restricted {
+		return true
+	}
+
+	_, ok := names[name]
+
+	return ok
+}
+
+// Get returns the instance with the given name, or nil if there is none.
+func (r *Registry) Get(name string) *Instance {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	return r.instances[name]
+}
+
+// Instances returns every registered instance sorted by name.
+func (r *Registry) Instances() []*Instance {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	instances := make([]*Instance, 0, len(r.instances))
+	for _, instance := range r.instances {
+		instances = append(instances, instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Name < instances[j].Name
+	})
+
+	return instances
+}
+
+// Close closes the token providers of every instance that implements
+// Closer, returning the first error.
+func (r *Registry) Close(ctx context.Context) error {
+	var first error
+
+	for _, instance := range r.Instances() {
+		closer, ok := instance.TokenProvider.(Closer)
+		if !ok {
+			continue
+		}
+
+		if err := closer.Close(ctx); err != nil && first == nil {
+			first = fmt.Errorf("error closing provider %s: %w", instance.Name, err)
+		}
+	}
+
+	return first
+}
+
+// IsType returns true if t is a provider type built into arcade.
+func IsType(t string) bool {
+	for _, typ := range types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get returns the provider instance with the given name from the
+// registry set on the context, or nil if it is not configured.
+func Get(c *gin.Context, name string) *Instance {
+	registry, exists := c.Get(Key)
+	if !exists {
+		return nil
+	}
+
+	return registry.(*Registry).Get(name)
+}
+
+// Allows returns true if the registry set on the context allows the API
+// key with the given name to use the instance with the given name.
+func Allows(c *gin.Context, apiKeyName, name string) bool {
+	registry, exists := c.Get(Key)
+	if !exists {
+		return false
+	}
+
+	return registry.(*Registry).Allows(apiKeyName, name)
+}
@@ -0,0 +1,168 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/homedepot/arcade/pkg/provider"
+	"github.com/homedepot/arcade/pkg/provider/providerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeCloser is a token provider that records being closed.
+type fakeCloser struct {
+	*providerfakes.FakeTokenProvider
+	closed bool
+	err    error
+}
+
+func (f *fakeCloser) Close(_ context.Context) error {
+	f.closed = true
+	return f.err
+}
+
+var _ = Describe("Registry", func() {
+	var (
+		registry          *Registry
+		fakeTokenProvider *providerfakes.FakeTokenProvider
+		err               error
+	)
+
+	BeforeEach(func() {
+		registry = NewRegistry()
+		fakeTokenProvider = &providerfakes.FakeTokenProvider{}
+		_ = registry.Register("rancher-prod", TypeRancher, fakeTokenProvider)
+		_ = registry.Register("rancher-lab", TypeRancher, fakeTokenProvider)
+	})
+
+	Describe("#Register", func() {
+		When("the name is not set", func() {
+			BeforeEach(func() {
+				err = registry.Register("", TypeGoogle, fakeTokenProvider)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("provider name not set"))
+			})
+		})
+
+		When("the token provider is not set", func() {
+			BeforeEach(func() {
+				err = registry.Register("gcp-ci", TypeGoogle, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("token provider not set"))
+			})
+		})
+
+		When("the name is used twice", func() {
+			BeforeEach(func() {
+				err = registry.Register("rancher-prod", TypeGoogle, fakeTokenProvider)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("duplicate provider name: rancher-prod"))
+			})
+		})
+
+		When("the type is not built in", func() {
+			BeforeEach(func() {
+				err = registry.Register("in-house", "custom", fakeTokenProvider)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(registry.Get("in-house").Type).To(Equal("custom"))
+			})
+		})
+	})
+
+	Describe("#RegisterClusters", func() {
+		When("the provider is not registered", func() {
+			BeforeEach(func() {
+				err = registry.RegisterClusters("fake", Cluster{Name: "prod"})
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("provider not registered: fake"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				err = registry.RegisterClusters("rancher-prod", Cluster{Name: "prod-east"}, Cluster{Name: "prod-west"})
+			})
+
+			It("adds the clusters to the instance", func() {
+				Expect(err).To(BeNil())
+				Expect(registry.Get("rancher-prod").Clusters).To(HaveLen(2))
+				Expect(registry.Get("rancher-lab").Clusters).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("#RestrictAPIKey", func() {
+		BeforeEach(func() {
+			registry.RestrictAPIKey("artifacts", "rancher-lab")
+		})
+
+		It("limits the API key to the instances", func() {
+			Expect(registry.Allows("artifacts", "rancher-lab")).To(BeTrue())
+			Expect(registry.Allows("artifacts", "rancher-prod")).To(BeFalse())
+		})
+
+		It("does not limit other API keys", func() {
+			Expect(registry.Allows("default", "rancher-prod")).To(BeTrue())
+		})
+	})
+
+	Describe("#Get", func() {
+		It("returns the instance with the name", func() {
+			instance := registry.Get("rancher-lab")
+			Expect(instance).ToNot(BeNil())
+			Expect(instance.Name).To(Equal("rancher-lab"))
+			Expect(instance.Type).To(Equal(TypeRancher))
+		})
+
+		It("returns nil for an unknown name", func() {
+			Expect(registry.Get("fake")).To(BeNil())
+		})
+	})
+
+	Describe("#Instances", func() {
+		It("returns the instances sorted by name", func() {
+			instances := registry.Instances()
+			Expect(instances).To(HaveLen(2))
+			Expect(instances[0].Name).To(Equal("rancher-lab"))
+			Expect(instances[1].Name).To(Equal("rancher-prod"))
+		})
+	})
+
+	Describe("#Close", func() {
+		var closers []*fakeCloser
+
+		BeforeEach(func() {
+			closers = []*fakeCloser{
+				{FakeTokenProvider: fakeTokenProvider, err: errors.New("error deleting token")},
+				{FakeTokenProvider: fakeTokenProvider},
+			}
+			_ = registry.Register("closer-a", TypeRancher, closers[0])
+			_ = registry.Register("closer-b", TypeRancher, closers[1])
+		})
+
+		It("closes every closer", func() {
+			err = registry.Close(context.Background())
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("error closing provider closer-a: error deleting token"))
+			Expect(closers[0].closed).To(BeTrue())
+			Expect(closers[1].closed).To(BeTrue())
+		})
+	})
+})
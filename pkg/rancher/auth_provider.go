@@ -0,0 +1,147 @@
+package rancher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	AuthProviderLocal           = "local"
+	AuthProviderActiveDirectory = "activedirectory"
+	AuthProviderOpenLDAP        = "openldap"
+	AuthProviderFreeIPA         = "freeipa"
+	AuthProviderAzureAD         = "azuread"
+)
+
+var (
+	// authProviderPaths are the paths of the login endpoints of the auth
+	// providers that accept a username and password.
+	authProviderPaths = map[string]string{
+		AuthProviderLocal:           "/v3-public/localProviders/local",
+		AuthProviderActiveDirectory: "/v3-public/activeDirectoryProviders/activedirectory",
+		AuthProviderOpenLDAP:        "/v3-public/openLdapProviders/openldap",
+		AuthProviderFreeIPA:         "/v3-public/freeIpaProviders/freeipa",
+		AuthProviderAzureAD:         "/v3-public/azureADProviders/azuread",
+	}
+	errUnsupportedAuthProviderFormat = "unsupported rancher auth provider %s, supported auth providers are %s"
+	errListAuthProvidersFormat       = "error listing rancher auth providers at %s: %s, check that the rancher URL is the base URL of the Rancher server, or its login URL if no auth provider is set"
+	errAuthProviderNotEnabledFormat  = "rancher auth provider %s is not enabled, enabled auth providers are %s"
+)
+
+type authProviderCollection struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// WithAuthProvider sets the auth provider to log in with, for example
+// AuthProviderActiveDirectory. The URL set with WithURL is then the base
+// URL of the Rancher server instead of a login URL.
+func (c *client) WithAuthProvider(authProvider string) {
+	c.authProvider = authProvider
+}
+
+// CheckAuthProvider checks that the Rancher server is reachable and, when
+// the client logs in, that its auth provider is enabled.
+func (c *client) CheckAuthProvider(ctx context.Context) error {
+	authProvider, err := c.authProviderName()
+	if err != nil {
+		return err
+	}
+
+	u, err := c.apiURL("/v3-public/authProviders")
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+
+	res, err := c.c.Do(req)
+	if err != nil {
+		return fmt.Errorf(errListAuthProvidersFormat, u, err.Error())
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		return fmt.Errorf(errListAuthProvidersFormat, u, res.Status)
+	}
+
+	collection := authProviderCollection{}
+
+	err = json.NewDecoder(res.Body).Decode(&collection)
+	if err != nil {
+		return fmt.Errorf(errListAuthProvidersFormat, u, err.Error())
+	}
+
+	// Derived tokens do not log in, so any auth provider will do.
+	if c.usesAPIKey() {
+		return nil
+	}
+
+	enabled := []string{}
+
+	for _, p := range collection.Data {
+		if p.ID == authProvider {
+			return nil
+		}
+
+		enabled = append(enabled, p.ID)
+	}
+
+	return fmt.Errorf(errAuthProviderNotEnabledFormat, authProvider, strings.Join(enabled, ", "))
+}
+
+// loginURL returns the URL the client logs in at. The URL of the client is
+// the login URL unless an auth provider is set.
+func (c *client) loginURL() (string, error) {
+	if c.authProvider == "" {
+		return c.url, nil
+	}
+
+	authProvider, err := c.authProviderName()
+	if err != nil {
+		return "", err
+	}
+
+	u, err := c.apiURL(authProviderPaths[authProvider])
+	if err != nil {
+		return "", err
+	}
+
+	return u + "?action=login", nil
+}
+
+// authProviderName returns the auth provider the client logs in with. If
+// none is set it is the last element of the path of the login URL.
+func (c *client) authProviderName() (string, error) {
+	if c.authProvider == "" {
+		path := strings.SplitN(c.url, "?", 2)[0]
+		return path[strings.LastIndex(path, "/")+1:], nil
+	}
+
+	if _, ok := authProviderPaths[c.authProvider]; !ok {
+		supported := make([]string, 0, len(authProviderPaths))
+		for p := range authProviderPaths {
+			supported = append(supported, p)
+		}
+
+		sort.Strings(supported)
+
+		return "", fmt.Errorf(errUnsupportedAuthProviderFormat, c.authProvider, strings.Join(supported, ", "))
+	}
+
+	return c.authProvider, nil
+}
@@ -8,45 +8,90 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-
-	"github.com/gin-gonic/gin"
-)
-
-const (
-	Key = "RancherClient"
+	"sync"
+	"time"
 )
 
 //go:generate counterfeiter . Client
 
 type Client interface {
 	NewToken(context.Context) (KubeconfigToken, error)
+	NewClusterToken(context.Context, string) (KubeconfigToken, error)
+	Clusters(context.Context) ([]Cluster, error)
+	ServerURL(string) (string, error)
+	CAData() ([]byte, error)
+	Tokens(context.Context) ([]KubeconfigToken, error)
+	Token(context.Context, string) (KubeconfigToken, error)
+	DeleteToken(context.Context, string) error
+	DeleteCreatedTokens(context.Context) error
 	WithURL(string)
 	WithUsername(string)
 	WithPassword(string)
+	WithAPIKey(string)
+	WithAPIKeyFile(string)
+	WithTTL(time.Duration)
+	WithDescription(string)
+	WithAuthProvider(string)
+	CheckAuthProvider(context.Context) error
 	WithTransport(*http.Transport)
+	WithCAFile(string)
+	WithTimeout(time.Duration)
+	WithTokensFile(string)
 }
 
 type NewTokenRequest struct {
 	ResponseType string `json:"responseType"`
 	Username     string `json:"username"`
 	Password     string `json:"password"`
+	Description  string `json:"description,omitempty"`
+	// TTL is the lifetime of the token in milliseconds. Rancher's default
+	// is used when it is zero.
+	TTL int64 `json:"ttl,omitempty"`
 }
 
+const (
+	responseTypeKubeconfig = "kubeconfig"
+)
+
 var (
-	errNotFoundFormat = "error getting token: %s"
+	errNotFoundFormat        = "error getting token: %s"
+	errClusterMismatchFormat = "error getting token: requested cluster %s, got cluster %s"
 )
 
 func NewClient() Client {
 	return &client{
-		c: &http.Client{},
+		c:       &http.Client{},
+		created: map[string]struct{}{},
 	}
 }
 
 type client struct {
-	url      string
-	username string
-	password string
-	c        *http.Client
+	mux sync.Mutex
+	// url is the base URL of the Rancher server or the login URL of an
+	// auth provider.
+	url          string
+	authProvider string
+	username     string
+	password     string
+	// apiKey and apiKeyFile are the API key, token-xxxxx:secret, that
+	// derived tokens are created with instead of logging in.
+	apiKey     string
+	apiKeyFile string
+	// ttl and description are requested for every token the client creates.
+	ttl         time.Duration
+	description string
+	c           *http.Client
+	// transport is the base transport of c, which trusts the CAs in caFile
+	// if it is set.
+	transport *http.Transport
+	caFile    string
+	// session is the token the client calls the Rancher API with.
+	session KubeconfigToken
+	// created are the IDs of the tokens the client created and has not
+	// deleted. They are kept in tokensFile if it is set.
+	createdMux sync.Mutex
+	created    map[string]struct{}
+	tokensFile string
 }
 
 func (c *client) WithURL(url string) {
@@ -61,17 +106,52 @@ func (c *client) WithPassword(password string) {
 	c.password = password
 }
 
-func (c *client) WithTransport(transport *http.Transport) {
-	c.c.Transport = transport
+// NewToken returns a kubeconfig token for every cluster the user can
+// access.
+func (c *client) NewToken(ctx context.Context) (KubeconfigToken, error) {
+	return c.newToken(ctx, "")
 }
 
-func (c *client) NewToken(ctx context.Context) (KubeconfigToken, error) {
+// NewClusterToken returns a kubeconfig token scoped to a single downstream
+// cluster, as required by authorized cluster endpoints.
+func (c *client) NewClusterToken(ctx context.Context, clusterID string) (KubeconfigToken, error) {
+	k, err := c.newToken(ctx, clusterID)
+	if err != nil {
+		return k, err
+	}
+
+	if k.ClusterID != clusterID {
+		return KubeconfigToken{}, fmt.Errorf(errClusterMismatchFormat, clusterID, k.ClusterID)
+	}
+
+	return k, nil
+}
+
+// newToken creates a token derived from the API key if one is set, or
+// logs in otherwise. The token is scoped to clusterID if it is set.
+func (c *client) newToken(ctx context.Context, clusterID string) (KubeconfigToken, error) {
+	if c.usesAPIKey() {
+		return c.newDerivedToken(ctx, clusterID)
+	}
+
+	return c.login(ctx, clusterID)
+}
+
+func (c *client) login(ctx context.Context, clusterID string) (KubeconfigToken, error) {
 	k := KubeconfigToken{}
 
+	// Rancher reads the cluster of the token from the response type.
+	responseType := responseTypeKubeconfig
+	if clusterID != "" {
+		responseType += "_" + clusterID
+	}
+
 	data := NewTokenRequest{
-		ResponseType: "kubeconfig",
+		ResponseType: responseType,
 		Username:     c.username,
 		Password:     c.password,
+		Description:  c.description,
+		TTL:          c.ttl.Milliseconds(),
 	}
 
 	b, err := json.Marshal(data)
@@ -79,7 +159,12 @@ func (c *client) NewToken(ctx context.Context) (KubeconfigToken, error) {
 		return k, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewBuffer(b))
+	u, err := c.loginURL()
+	if err != nil {
+		return k, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewBuffer(b))
 	if err != nil {
 		return k, err
 	}
@@ -104,14 +189,125 @@ func (c *client) NewToken(ctx context.Context) (KubeconfigToken, error) {
 		return k, err
 	}
 
+	err = c.checkTTL(ctx, k)
+	if err != nil {
+		return KubeconfigToken{}, err
+	}
+
+	c.track(k.ID)
+
 	return k, nil
 }
 
-func Instance(c *gin.Context) Client {
-	instance, exists := c.Get(Key)
-	if exists {
-		return instance.(Client)
+// do calls the Rancher API with the session token, sending body as JSON
+// and decoding the response into v if they are not nil. Responses other
+// than status are returned as an error of errFormat, whose last argument
+// is the response status. A missing resource is not an error when deleting.
+func (c *client) do(ctx context.Context, method, u string, body interface{}, status int, v interface{}, errFormat string, args ...interface{}) error {
+	token, err := c.sessionToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.doWithToken(ctx, token, method, u, body, status, v, errFormat, args...)
+}
+
+// doWithToken calls the Rancher API like do, with the given token.
+func (c *client) doWithToken(ctx context.Context, token, method, u string, body interface{}, status int, v interface{}, errFormat string, args ...interface{}) error {
+	var r io.Reader
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		r = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequest(method, u, r)
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	res, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if method == http.MethodDelete && (res.StatusCode == http.StatusOK || res.StatusCode == http.StatusNotFound) {
+		status = res.StatusCode
+	}
+
+	if res.StatusCode != status {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+
+		if res.StatusCode == http.StatusUnauthorized {
+			c.resetSession()
+		}
+
+		return statusError{
+			code: res.StatusCode,
+			err:  fmt.Errorf(errFormat, append(args, res.Status)...),
+		}
+	}
+
+	if v == nil {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// statusError is returned for responses with an unexpected status.
+type statusError struct {
+	code int
+	err  error
+}
+
+func (e statusError) Error() string {
+	return e.err.Error()
+}
+
+func (c *client) track(id string) {
+	if id == "" {
+		return
 	}
 
-	return nil
+	c.createdMux.Lock()
+	defer c.createdMux.Unlock()
+
+	c.created[id] = struct{}{}
+	c.writeTokensFile()
+}
+
+func (c *client) untrack(id string) {
+	c.createdMux.Lock()
+	defer c.createdMux.Unlock()
+
+	if _, ok := c.created[id]; !ok {
+		return
+	}
+
+	delete(c.created, id)
+	c.writeTokensFile()
+}
+
+func (c *client) tracked(id string) bool {
+	c.createdMux.Lock()
+	defer c.createdMux.Unlock()
+
+	_, ok := c.created[id]
+
+	return ok
 }
@@ -0,0 +1,114 @@
+package rancher
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// sessionExpirySkew is how long before its expiry the token used to call
+// the Rancher API is replaced.
+const sessionExpirySkew = 1 * time.Minute
+
+var (
+	errListClustersFormat = "error listing clusters: %s"
+)
+
+// Cluster is a downstream cluster managed by Rancher.
+type Cluster struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+	// APIEndpoint is the API server of the cluster, which is reachable
+	// directly when authorized cluster endpoints are enabled.
+	APIEndpoint string `json:"apiEndpoint"`
+	// CACert is the base64 encoded PEM CA of the API endpoint.
+	CACert string `json:"caCert"`
+}
+
+// CA returns the decoded PEM CA of the cluster's API endpoint.
+func (c Cluster) CA() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(c.CACert)
+}
+
+type clusterCollection struct {
+	Data []Cluster `json:"data"`
+}
+
+// Clusters returns the downstream clusters the configured user can access.
+func (c *client) Clusters(ctx context.Context) ([]Cluster, error) {
+	u, err := c.apiURL("/v3/clusters")
+	if err != nil {
+		return nil, err
+	}
+
+	collection := clusterCollection{}
+
+	err = c.do(ctx, http.MethodGet, u, nil, http.StatusOK, &collection, errListClustersFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Data, nil
+}
+
+// ServerURL returns the URL of the Rancher proxy to the API server of a
+// downstream cluster.
+func (c *client) ServerURL(clusterID string) (string, error) {
+	return c.apiURL("/k8s/clusters/" + clusterID)
+}
+
+// apiURL returns path on the Rancher server. The URL of the client is the
+// base URL of the server if an auth provider is set, otherwise it is a
+// login URL, which is on the root of the server.
+func (c *client) apiURL(path string) (string, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return "", err
+	}
+
+	if c.authProvider == "" {
+		return u.ResolveReference(&url.URL{Path: path}).String(), nil
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawQuery = ""
+
+	return u.String(), nil
+}
+
+// sessionToken returns the token used to call the Rancher API. It is the
+// API key if one is set, or a token that is replaced by logging in again
+// when it is about to expire.
+func (c *client) sessionToken(ctx context.Context) (string, error) {
+	if c.usesAPIKey() {
+		return c.readAPIKey()
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.session.Token != "" &&
+		(c.session.ExpiresAt.IsZero() || time.Now().Add(sessionExpirySkew).Before(c.session.ExpiresAt)) {
+		return c.session.Token, nil
+	}
+
+	k, err := c.login(ctx, "")
+	if err != nil {
+		return "", err
+	}
+
+	c.session = k
+
+	return k.Token, nil
+}
+
+func (c *client) resetSession() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.session = KubeconfigToken{}
+}
@@ -0,0 +1,53 @@
+package rancher
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+)
+
+// DescriptionData is passed to description templates.
+type DescriptionData struct {
+	// Hostname is the hostname of arcade, which is the name of the pod
+	// when it runs in Kubernetes.
+	Hostname string
+	// Provider is the name of the provider instance.
+	Provider string
+}
+
+// NewDescription executes the description template tmpl, for example
+// arcade/{{.Hostname}}/{{.Provider}}. Environment variables are read with
+// the env function, as in {{env "POD_NAME"}}.
+func NewDescription(tmpl string, data DescriptionData) (string, error) {
+	t, err := template.New("description").
+		Funcs(template.FuncMap{"env": os.Getenv}).
+		Option("missingkey=error").
+		Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("error parsing description template: %w", err)
+	}
+
+	b := &bytes.Buffer{}
+
+	err = t.Execute(b, data)
+	if err != nil {
+		return "", fmt.Errorf("error executing description template: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+// WithDescription sets the description of the tokens the client creates.
+func (c *client) WithDescription(description string) {
+	c.description = description
+}
+
+// derivedDescription returns the description of derived tokens.
+func (c *client) derivedDescription() string {
+	if c.description == "" {
+		return DefaultDescription
+	}
+
+	return c.description
+}
@@ -0,0 +1,48 @@
+package rancher_test
+
+import (
+	"os"
+
+	. "github.com/homedepot/arcade/pkg/rancher"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Description", func() {
+	Describe("#NewDescription", func() {
+		var (
+			description string
+			err         error
+		)
+
+		BeforeEach(func() {
+			os.Setenv("ARCADE_TEST_POD_NAME", "arcade-5d8f7c9b4-x2k8p")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("ARCADE_TEST_POD_NAME")
+		})
+
+		It("executes the template", func() {
+			description, err = NewDescription(`arcade/{{.Hostname}}/{{.Provider}}/{{env "ARCADE_TEST_POD_NAME"}}`, DescriptionData{
+				Hostname: "node-1",
+				Provider: "rancher-prod",
+			})
+			Expect(err).To(BeNil())
+			Expect(description).To(Equal("arcade/node-1/rancher-prod/arcade-5d8f7c9b4-x2k8p"))
+		})
+
+		It("returns an error for an invalid template", func() {
+			_, err = NewDescription(`arcade/{{.Hostname`, DescriptionData{})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(HavePrefix("error parsing description template"))
+		})
+
+		It("returns an error for an unknown field", func() {
+			_, err = NewDescription(`arcade/{{.Pod}}`, DescriptionData{})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(HavePrefix("error executing description template"))
+		})
+	})
+})
@@ -1,11 +1,14 @@
 package rancher
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type KubeconfigToken struct {
 	AuthProvider    string      `json:"authProvider"`
 	BaseType        string      `json:"baseType"`
-	ClusterID       interface{} `json:"clusterId"`
+	ClusterID       string      `json:"clusterId"`
 	Created         time.Time   `json:"created"`
 	CreatedTS       int64       `json:"createdTS"`
 	CreatorID       interface{} `json:"creatorId"`
@@ -34,3 +37,37 @@ type KubeconfigToken struct {
 	UserPrincipal string `json:"userPrincipal"`
 	UUID          string `json:"uuid"`
 }
+
+// UnmarshalJSON decodes a token whose expiresAt is empty, as it is for
+// tokens with a TTL of 0, into a token with a zero ExpiresAt.
+func (k *KubeconfigToken) UnmarshalJSON(b []byte) error {
+	type kubeconfigToken KubeconfigToken
+
+	aux := struct {
+		*kubeconfigToken
+		ExpiresAt string `json:"expiresAt"`
+	}{
+		kubeconfigToken: (*kubeconfigToken)(k),
+	}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+
+	k.ExpiresAt = time.Time{}
+
+	if aux.ExpiresAt != "" {
+		k.ExpiresAt, err = time.Parse(time.RFC3339, aux.ExpiresAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Expires returns false for tokens that never expire.
+func (k KubeconfigToken) Expires() bool {
+	return !k.ExpiresAt.IsZero()
+}
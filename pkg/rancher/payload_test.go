@@ -32,3 +32,148 @@ const payloadKubeconfigToken = `{
   "userPrincipal": "map[metadata:map[creationTimestamp:<nil>]]",
   "uuid": "bf897e53-3cb3-49d0-af44-09343f75ec2e"
 }`
+
+const payloadClusterKubeconfigToken = `{
+  "authProvider": "local",
+  "baseType": "token",
+  "clusterId": "c-abc12",
+  "created": "2021-03-25T10:38:18Z",
+  "createdTS": 1616668698000,
+  "creatorId": null,
+  "current": false,
+  "description": "Kubeconfig token",
+  "enabled": true,
+  "expired": false,
+  "expiresAt": "2021-03-25T20:38:18Z",
+  "groupPrincipals": null,
+  "id": "kubeconfig-u-i76rfanbw5",
+  "isDerived": true,
+  "labels": {
+    "authn.management.cattle.io/kind": "kubeconfig",
+    "authn.management.cattle.io/token-userId": "u-i76rfanbw5",
+    "cattle.io/creator": "norman"
+  },
+  "lastUpdateTime": "",
+  "links": {
+    "self": "https://rancher.example.com/v3/tokens/kubeconfig-u-i76rfanbw5"
+  },
+  "name": "kubeconfig-u-i76rfanbw5",
+  "token": "kubeconfig-u-i76rfanbw5:ltqlpxqz5hh52sxfxfbxxkk6xw7pzkh7d922cww6m9x6fjskskxwl9",
+  "ttl": 36000000,
+  "type": "token",
+  "userId": "u-i76rfanbw5",
+  "userPrincipal": "map[metadata:map[creationTimestamp:<nil>]]",
+  "uuid": "bf897e53-3cb3-49d0-af44-09343f75ec2e"
+}`
+
+const payloadClusters = `{
+  "type": "collection",
+  "resourceType": "cluster",
+  "data": [
+    {
+      "id": "c-abc12",
+      "name": "prod-east",
+      "state": "active",
+      "apiEndpoint": "https://10.0.0.1:6443",
+      "caCert": "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t",
+      "type": "cluster"
+    },
+    {
+      "id": "local",
+      "name": "local",
+      "state": "active",
+      "apiEndpoint": "https://10.0.0.2:6443",
+      "caCert": "",
+      "type": "cluster"
+    }
+  ]
+}`
+
+const payloadTokensPage1 = `{
+  "type": "collection",
+  "resourceType": "token",
+  "pagination": {
+    "next": "%s/v3/tokens?marker=page2"
+  },
+  "data": [
+    {
+      "id": "kubeconfig-u-session",
+      "current": true,
+      "labels": {
+        "authn.management.cattle.io/kind": "kubeconfig"
+      }
+    },
+    {
+      "id": "kubeconfig-u-stale1",
+      "current": false,
+      "expiresAt": "2021-03-25T20:38:18Z",
+      "labels": {
+        "authn.management.cattle.io/kind": "kubeconfig"
+      }
+    },
+    {
+      "id": "token-ui123",
+      "current": false,
+      "labels": {}
+    }
+  ]
+}`
+
+const payloadTokensPage2 = `{
+  "type": "collection",
+  "resourceType": "token",
+  "pagination": {},
+  "data": [
+    {
+      "id": "kubeconfig-u-stale2",
+      "current": false,
+      "clusterId": "c-abc12",
+      "labels": {
+        "authn.management.cattle.io/kind": "kubeconfig"
+      }
+    }
+  ]
+}`
+
+const payloadDerivedToken = `{
+  "authProvider": "local",
+  "baseType": "token",
+  "clusterId": "",
+  "created": "2021-03-25T10:38:18Z",
+  "current": false,
+  "description": "arcade",
+  "enabled": true,
+  "expired": false,
+  "expiresAt": "2021-03-25T11:38:18Z",
+  "id": "token-derived1",
+  "isDerived": true,
+  "links": {
+    "self": "https://rancher.example.com/v3/tokens/token-derived1"
+  },
+  "name": "token-derived1",
+  "token": "token-derived1:bf897e533cb349d0af4409343f75ec2e",
+  "ttl": 3600000,
+  "type": "token",
+  "userId": "u-i76rfanbw5"
+}`
+
+const payloadAuthProviders = `{
+  "type": "collection",
+  "resourceType": "authProvider",
+  "data": [
+    {
+      "id": "local",
+      "type": "localProvider",
+      "actions": {
+        "login": "https://rancher.example.com/v3-public/localProviders/local?action=login"
+      }
+    },
+    {
+      "id": "activedirectory",
+      "type": "activeDirectoryProvider",
+      "actions": {
+        "login": "https://rancher.example.com/v3-public/activeDirectoryProviders/activedirectory?action=login"
+      }
+    }
+  ]
+}`
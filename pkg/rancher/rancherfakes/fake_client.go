@@ -5,11 +5,85 @@ import (
 	"context"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/homedepot/arcade/pkg/rancher"
 )
 
 type FakeClient struct {
+	CADataStub        func() ([]byte, error)
+	cADataMutex       sync.RWMutex
+	cADataArgsForCall []struct {
+	}
+	cADataReturns struct {
+		result1 []byte
+		result2 error
+	}
+	cADataReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	CheckAuthProviderStub        func(context.Context) error
+	checkAuthProviderMutex       sync.RWMutex
+	checkAuthProviderArgsForCall []struct {
+		arg1 context.Context
+	}
+	checkAuthProviderReturns struct {
+		result1 error
+	}
+	checkAuthProviderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ClustersStub        func(context.Context) ([]rancher.Cluster, error)
+	clustersMutex       sync.RWMutex
+	clustersArgsForCall []struct {
+		arg1 context.Context
+	}
+	clustersReturns struct {
+		result1 []rancher.Cluster
+		result2 error
+	}
+	clustersReturnsOnCall map[int]struct {
+		result1 []rancher.Cluster
+		result2 error
+	}
+	DeleteCreatedTokensStub        func(context.Context) error
+	deleteCreatedTokensMutex       sync.RWMutex
+	deleteCreatedTokensArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteCreatedTokensReturns struct {
+		result1 error
+	}
+	deleteCreatedTokensReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteTokenStub        func(context.Context, string) error
+	deleteTokenMutex       sync.RWMutex
+	deleteTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteTokenReturns struct {
+		result1 error
+	}
+	deleteTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NewClusterTokenStub        func(context.Context, string) (rancher.KubeconfigToken, error)
+	newClusterTokenMutex       sync.RWMutex
+	newClusterTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	newClusterTokenReturns struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}
+	newClusterTokenReturnsOnCall map[int]struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}
 	NewTokenStub        func(context.Context) (rancher.KubeconfigToken, error)
 	newTokenMutex       sync.RWMutex
 	newTokenArgsForCall []struct {
@@ -23,11 +97,91 @@ type FakeClient struct {
 		result1 rancher.KubeconfigToken
 		result2 error
 	}
+	ServerURLStub        func(string) (string, error)
+	serverURLMutex       sync.RWMutex
+	serverURLArgsForCall []struct {
+		arg1 string
+	}
+	serverURLReturns struct {
+		result1 string
+		result2 error
+	}
+	serverURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	TokenStub        func(context.Context, string) (rancher.KubeconfigToken, error)
+	tokenMutex       sync.RWMutex
+	tokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	tokenReturns struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}
+	tokenReturnsOnCall map[int]struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}
+	TokensStub        func(context.Context) ([]rancher.KubeconfigToken, error)
+	tokensMutex       sync.RWMutex
+	tokensArgsForCall []struct {
+		arg1 context.Context
+	}
+	tokensReturns struct {
+		result1 []rancher.KubeconfigToken
+		result2 error
+	}
+	tokensReturnsOnCall map[int]struct {
+		result1 []rancher.KubeconfigToken
+		result2 error
+	}
+	WithAPIKeyStub        func(string)
+	withAPIKeyMutex       sync.RWMutex
+	withAPIKeyArgsForCall []struct {
+		arg1 string
+	}
+	WithAPIKeyFileStub        func(string)
+	withAPIKeyFileMutex       sync.RWMutex
+	withAPIKeyFileArgsForCall []struct {
+		arg1 string
+	}
+	WithAuthProviderStub        func(string)
+	withAuthProviderMutex       sync.RWMutex
+	withAuthProviderArgsForCall []struct {
+		arg1 string
+	}
+	WithCAFileStub        func(string)
+	withCAFileMutex       sync.RWMutex
+	withCAFileArgsForCall []struct {
+		arg1 string
+	}
+	WithDescriptionStub        func(string)
+	withDescriptionMutex       sync.RWMutex
+	withDescriptionArgsForCall []struct {
+		arg1 string
+	}
 	WithPasswordStub        func(string)
 	withPasswordMutex       sync.RWMutex
 	withPasswordArgsForCall []struct {
 		arg1 string
 	}
+	WithTTLStub        func(time.Duration)
+	withTTLMutex       sync.RWMutex
+	withTTLArgsForCall []struct {
+		arg1 time.Duration
+	}
+	WithTimeoutStub        func(time.Duration)
+	withTimeoutMutex       sync.RWMutex
+	withTimeoutArgsForCall []struct {
+		arg1 time.Duration
+	}
+	WithTokensFileStub        func(string)
+	withTokensFileMutex       sync.RWMutex
+	withTokensFileArgsForCall []struct {
+		arg1 string
+	}
 	WithTransportStub        func(*http.Transport)
 	withTransportMutex       sync.RWMutex
 	withTransportArgsForCall []struct {
@@ -47,21 +201,391 @@ type FakeClient struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeClient) CAData() ([]byte, error) {
+	fake.cADataMutex.Lock()
+	ret, specificReturn := fake.cADataReturnsOnCall[len(fake.cADataArgsForCall)]
+	fake.cADataArgsForCall = append(fake.cADataArgsForCall, struct {
+	}{})
+	stub := fake.CADataStub
+	fakeReturns := fake.cADataReturns
+	fake.recordInvocation("CAData", []interface{}{})
+	fake.cADataMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) CADataCallCount() int {
+	fake.cADataMutex.RLock()
+	defer fake.cADataMutex.RUnlock()
+	return len(fake.cADataArgsForCall)
+}
+
+func (fake *FakeClient) CADataCalls(stub func() ([]byte, error)) {
+	fake.cADataMutex.Lock()
+	defer fake.cADataMutex.Unlock()
+	fake.CADataStub = stub
+}
+
+func (fake *FakeClient) CADataReturns(result1 []byte, result2 error) {
+	fake.cADataMutex.Lock()
+	defer fake.cADataMutex.Unlock()
+	fake.CADataStub = nil
+	fake.cADataReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CADataReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.cADataMutex.Lock()
+	defer fake.cADataMutex.Unlock()
+	fake.CADataStub = nil
+	if fake.cADataReturnsOnCall == nil {
+		fake.cADataReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.cADataReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CheckAuthProvider(arg1 context.Context) error {
+	fake.checkAuthProviderMutex.Lock()
+	ret, specificReturn := fake.checkAuthProviderReturnsOnCall[len(fake.checkAuthProviderArgsForCall)]
+	fake.checkAuthProviderArgsForCall = append(fake.checkAuthProviderArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.CheckAuthProviderStub
+	fakeReturns := fake.checkAuthProviderReturns
+	fake.recordInvocation("CheckAuthProvider", []interface{}{arg1})
+	fake.checkAuthProviderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CheckAuthProviderCallCount() int {
+	fake.checkAuthProviderMutex.RLock()
+	defer fake.checkAuthProviderMutex.RUnlock()
+	return len(fake.checkAuthProviderArgsForCall)
+}
+
+func (fake *FakeClient) CheckAuthProviderCalls(stub func(context.Context) error) {
+	fake.checkAuthProviderMutex.Lock()
+	defer fake.checkAuthProviderMutex.Unlock()
+	fake.CheckAuthProviderStub = stub
+}
+
+func (fake *FakeClient) CheckAuthProviderArgsForCall(i int) context.Context {
+	fake.checkAuthProviderMutex.RLock()
+	defer fake.checkAuthProviderMutex.RUnlock()
+	argsForCall := fake.checkAuthProviderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CheckAuthProviderReturns(result1 error) {
+	fake.checkAuthProviderMutex.Lock()
+	defer fake.checkAuthProviderMutex.Unlock()
+	fake.CheckAuthProviderStub = nil
+	fake.checkAuthProviderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CheckAuthProviderReturnsOnCall(i int, result1 error) {
+	fake.checkAuthProviderMutex.Lock()
+	defer fake.checkAuthProviderMutex.Unlock()
+	fake.CheckAuthProviderStub = nil
+	if fake.checkAuthProviderReturnsOnCall == nil {
+		fake.checkAuthProviderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkAuthProviderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) Clusters(arg1 context.Context) ([]rancher.Cluster, error) {
+	fake.clustersMutex.Lock()
+	ret, specificReturn := fake.clustersReturnsOnCall[len(fake.clustersArgsForCall)]
+	fake.clustersArgsForCall = append(fake.clustersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ClustersStub
+	fakeReturns := fake.clustersReturns
+	fake.recordInvocation("Clusters", []interface{}{arg1})
+	fake.clustersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ClustersCallCount() int {
+	fake.clustersMutex.RLock()
+	defer fake.clustersMutex.RUnlock()
+	return len(fake.clustersArgsForCall)
+}
+
+func (fake *FakeClient) ClustersCalls(stub func(context.Context) ([]rancher.Cluster, error)) {
+	fake.clustersMutex.Lock()
+	defer fake.clustersMutex.Unlock()
+	fake.ClustersStub = stub
+}
+
+func (fake *FakeClient) ClustersArgsForCall(i int) context.Context {
+	fake.clustersMutex.RLock()
+	defer fake.clustersMutex.RUnlock()
+	argsForCall := fake.clustersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ClustersReturns(result1 []rancher.Cluster, result2 error) {
+	fake.clustersMutex.Lock()
+	defer fake.clustersMutex.Unlock()
+	fake.ClustersStub = nil
+	fake.clustersReturns = struct {
+		result1 []rancher.Cluster
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ClustersReturnsOnCall(i int, result1 []rancher.Cluster, result2 error) {
+	fake.clustersMutex.Lock()
+	defer fake.clustersMutex.Unlock()
+	fake.ClustersStub = nil
+	if fake.clustersReturnsOnCall == nil {
+		fake.clustersReturnsOnCall = make(map[int]struct {
+			result1 []rancher.Cluster
+			result2 error
+		})
+	}
+	fake.clustersReturnsOnCall[i] = struct {
+		result1 []rancher.Cluster
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DeleteCreatedTokens(arg1 context.Context) error {
+	fake.deleteCreatedTokensMutex.Lock()
+	ret, specificReturn := fake.deleteCreatedTokensReturnsOnCall[len(fake.deleteCreatedTokensArgsForCall)]
+	fake.deleteCreatedTokensArgsForCall = append(fake.deleteCreatedTokensArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteCreatedTokensStub
+	fakeReturns := fake.deleteCreatedTokensReturns
+	fake.recordInvocation("DeleteCreatedTokens", []interface{}{arg1})
+	fake.deleteCreatedTokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) DeleteCreatedTokensCallCount() int {
+	fake.deleteCreatedTokensMutex.RLock()
+	defer fake.deleteCreatedTokensMutex.RUnlock()
+	return len(fake.deleteCreatedTokensArgsForCall)
+}
+
+func (fake *FakeClient) DeleteCreatedTokensCalls(stub func(context.Context) error) {
+	fake.deleteCreatedTokensMutex.Lock()
+	defer fake.deleteCreatedTokensMutex.Unlock()
+	fake.DeleteCreatedTokensStub = stub
+}
+
+func (fake *FakeClient) DeleteCreatedTokensArgsForCall(i int) context.Context {
+	fake.deleteCreatedTokensMutex.RLock()
+	defer fake.deleteCreatedTokensMutex.RUnlock()
+	argsForCall := fake.deleteCreatedTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DeleteCreatedTokensReturns(result1 error) {
+	fake.deleteCreatedTokensMutex.Lock()
+	defer fake.deleteCreatedTokensMutex.Unlock()
+	fake.DeleteCreatedTokensStub = nil
+	fake.deleteCreatedTokensReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteCreatedTokensReturnsOnCall(i int, result1 error) {
+	fake.deleteCreatedTokensMutex.Lock()
+	defer fake.deleteCreatedTokensMutex.Unlock()
+	fake.DeleteCreatedTokensStub = nil
+	if fake.deleteCreatedTokensReturnsOnCall == nil {
+		fake.deleteCreatedTokensReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteCreatedTokensReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteToken(arg1 context.Context, arg2 string) error {
+	fake.deleteTokenMutex.Lock()
+	ret, specificReturn := fake.deleteTokenReturnsOnCall[len(fake.deleteTokenArgsForCall)]
+	fake.deleteTokenArgsForCall = append(fake.deleteTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteTokenStub
+	fakeReturns := fake.deleteTokenReturns
+	fake.recordInvocation("DeleteToken", []interface{}{arg1, arg2})
+	fake.deleteTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) DeleteTokenCallCount() int {
+	fake.deleteTokenMutex.RLock()
+	defer fake.deleteTokenMutex.RUnlock()
+	return len(fake.deleteTokenArgsForCall)
+}
+
+func (fake *FakeClient) DeleteTokenCalls(stub func(context.Context, string) error) {
+	fake.deleteTokenMutex.Lock()
+	defer fake.deleteTokenMutex.Unlock()
+	fake.DeleteTokenStub = stub
+}
+
+func (fake *FakeClient) DeleteTokenArgsForCall(i int) (context.Context, string) {
+	fake.deleteTokenMutex.RLock()
+	defer fake.deleteTokenMutex.RUnlock()
+	argsForCall := fake.deleteTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) DeleteTokenReturns(result1 error) {
+	fake.deleteTokenMutex.Lock()
+	defer fake.deleteTokenMutex.Unlock()
+	fake.DeleteTokenStub = nil
+	fake.deleteTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteTokenReturnsOnCall(i int, result1 error) {
+	fake.deleteTokenMutex.Lock()
+	defer fake.deleteTokenMutex.Unlock()
+	fake.DeleteTokenStub = nil
+	if fake.deleteTokenReturnsOnCall == nil {
+		fake.deleteTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) NewClusterToken(arg1 context.Context, arg2 string) (rancher.KubeconfigToken, error) {
+	fake.newClusterTokenMutex.Lock()
+	ret, specificReturn := fake.newClusterTokenReturnsOnCall[len(fake.newClusterTokenArgsForCall)]
+	fake.newClusterTokenArgsForCall = append(fake.newClusterTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NewClusterTokenStub
+	fakeReturns := fake.newClusterTokenReturns
+	fake.recordInvocation("NewClusterToken", []interface{}{arg1, arg2})
+	fake.newClusterTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewClusterTokenCallCount() int {
+	fake.newClusterTokenMutex.RLock()
+	defer fake.newClusterTokenMutex.RUnlock()
+	return len(fake.newClusterTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewClusterTokenCalls(stub func(context.Context, string) (rancher.KubeconfigToken, error)) {
+	fake.newClusterTokenMutex.Lock()
+	defer fake.newClusterTokenMutex.Unlock()
+	fake.NewClusterTokenStub = stub
+}
+
+func (fake *FakeClient) NewClusterTokenArgsForCall(i int) (context.Context, string) {
+	fake.newClusterTokenMutex.RLock()
+	defer fake.newClusterTokenMutex.RUnlock()
+	argsForCall := fake.newClusterTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) NewClusterTokenReturns(result1 rancher.KubeconfigToken, result2 error) {
+	fake.newClusterTokenMutex.Lock()
+	defer fake.newClusterTokenMutex.Unlock()
+	fake.NewClusterTokenStub = nil
+	fake.newClusterTokenReturns = struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewClusterTokenReturnsOnCall(i int, result1 rancher.KubeconfigToken, result2 error) {
+	fake.newClusterTokenMutex.Lock()
+	defer fake.newClusterTokenMutex.Unlock()
+	fake.NewClusterTokenStub = nil
+	if fake.newClusterTokenReturnsOnCall == nil {
+		fake.newClusterTokenReturnsOnCall = make(map[int]struct {
+			result1 rancher.KubeconfigToken
+			result2 error
+		})
+	}
+	fake.newClusterTokenReturnsOnCall[i] = struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) NewToken(arg1 context.Context) (rancher.KubeconfigToken, error) {
 	fake.newTokenMutex.Lock()
 	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
 	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
 		arg1 context.Context
 	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
 	fake.recordInvocation("NewToken", []interface{}{arg1})
 	fake.newTokenMutex.Unlock()
-	if fake.NewTokenStub != nil {
-		return fake.NewTokenStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.newTokenReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -110,14 +634,368 @@ func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 rancher.KubeconfigT
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ServerURL(arg1 string) (string, error) {
+	fake.serverURLMutex.Lock()
+	ret, specificReturn := fake.serverURLReturnsOnCall[len(fake.serverURLArgsForCall)]
+	fake.serverURLArgsForCall = append(fake.serverURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ServerURLStub
+	fakeReturns := fake.serverURLReturns
+	fake.recordInvocation("ServerURL", []interface{}{arg1})
+	fake.serverURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ServerURLCallCount() int {
+	fake.serverURLMutex.RLock()
+	defer fake.serverURLMutex.RUnlock()
+	return len(fake.serverURLArgsForCall)
+}
+
+func (fake *FakeClient) ServerURLCalls(stub func(string) (string, error)) {
+	fake.serverURLMutex.Lock()
+	defer fake.serverURLMutex.Unlock()
+	fake.ServerURLStub = stub
+}
+
+func (fake *FakeClient) ServerURLArgsForCall(i int) string {
+	fake.serverURLMutex.RLock()
+	defer fake.serverURLMutex.RUnlock()
+	argsForCall := fake.serverURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ServerURLReturns(result1 string, result2 error) {
+	fake.serverURLMutex.Lock()
+	defer fake.serverURLMutex.Unlock()
+	fake.ServerURLStub = nil
+	fake.serverURLReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ServerURLReturnsOnCall(i int, result1 string, result2 error) {
+	fake.serverURLMutex.Lock()
+	defer fake.serverURLMutex.Unlock()
+	fake.ServerURLStub = nil
+	if fake.serverURLReturnsOnCall == nil {
+		fake.serverURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.serverURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Token(arg1 context.Context, arg2 string) (rancher.KubeconfigToken, error) {
+	fake.tokenMutex.Lock()
+	ret, specificReturn := fake.tokenReturnsOnCall[len(fake.tokenArgsForCall)]
+	fake.tokenArgsForCall = append(fake.tokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.TokenStub
+	fakeReturns := fake.tokenReturns
+	fake.recordInvocation("Token", []interface{}{arg1, arg2})
+	fake.tokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) TokenCallCount() int {
+	fake.tokenMutex.RLock()
+	defer fake.tokenMutex.RUnlock()
+	return len(fake.tokenArgsForCall)
+}
+
+func (fake *FakeClient) TokenCalls(stub func(context.Context, string) (rancher.KubeconfigToken, error)) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = stub
+}
+
+func (fake *FakeClient) TokenArgsForCall(i int) (context.Context, string) {
+	fake.tokenMutex.RLock()
+	defer fake.tokenMutex.RUnlock()
+	argsForCall := fake.tokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) TokenReturns(result1 rancher.KubeconfigToken, result2 error) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = nil
+	fake.tokenReturns = struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) TokenReturnsOnCall(i int, result1 rancher.KubeconfigToken, result2 error) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = nil
+	if fake.tokenReturnsOnCall == nil {
+		fake.tokenReturnsOnCall = make(map[int]struct {
+			result1 rancher.KubeconfigToken
+			result2 error
+		})
+	}
+	fake.tokenReturnsOnCall[i] = struct {
+		result1 rancher.KubeconfigToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Tokens(arg1 context.Context) ([]rancher.KubeconfigToken, error) {
+	fake.tokensMutex.Lock()
+	ret, specificReturn := fake.tokensReturnsOnCall[len(fake.tokensArgsForCall)]
+	fake.tokensArgsForCall = append(fake.tokensArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.TokensStub
+	fakeReturns := fake.tokensReturns
+	fake.recordInvocation("Tokens", []interface{}{arg1})
+	fake.tokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) TokensCallCount() int {
+	fake.tokensMutex.RLock()
+	defer fake.tokensMutex.RUnlock()
+	return len(fake.tokensArgsForCall)
+}
+
+func (fake *FakeClient) TokensCalls(stub func(context.Context) ([]rancher.KubeconfigToken, error)) {
+	fake.tokensMutex.Lock()
+	defer fake.tokensMutex.Unlock()
+	fake.TokensStub = stub
+}
+
+func (fake *FakeClient) TokensArgsForCall(i int) context.Context {
+	fake.tokensMutex.RLock()
+	defer fake.tokensMutex.RUnlock()
+	argsForCall := fake.tokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) TokensReturns(result1 []rancher.KubeconfigToken, result2 error) {
+	fake.tokensMutex.Lock()
+	defer fake.tokensMutex.Unlock()
+	fake.TokensStub = nil
+	fake.tokensReturns = struct {
+		result1 []rancher.KubeconfigToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) TokensReturnsOnCall(i int, result1 []rancher.KubeconfigToken, result2 error) {
+	fake.tokensMutex.Lock()
+	defer fake.tokensMutex.Unlock()
+	fake.TokensStub = nil
+	if fake.tokensReturnsOnCall == nil {
+		fake.tokensReturnsOnCall = make(map[int]struct {
+			result1 []rancher.KubeconfigToken
+			result2 error
+		})
+	}
+	fake.tokensReturnsOnCall[i] = struct {
+		result1 []rancher.KubeconfigToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WithAPIKey(arg1 string) {
+	fake.withAPIKeyMutex.Lock()
+	fake.withAPIKeyArgsForCall = append(fake.withAPIKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAPIKeyStub
+	fake.recordInvocation("WithAPIKey", []interface{}{arg1})
+	fake.withAPIKeyMutex.Unlock()
+	if stub != nil {
+		fake.WithAPIKeyStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAPIKeyCallCount() int {
+	fake.withAPIKeyMutex.RLock()
+	defer fake.withAPIKeyMutex.RUnlock()
+	return len(fake.withAPIKeyArgsForCall)
+}
+
+func (fake *FakeClient) WithAPIKeyCalls(stub func(string)) {
+	fake.withAPIKeyMutex.Lock()
+	defer fake.withAPIKeyMutex.Unlock()
+	fake.WithAPIKeyStub = stub
+}
+
+func (fake *FakeClient) WithAPIKeyArgsForCall(i int) string {
+	fake.withAPIKeyMutex.RLock()
+	defer fake.withAPIKeyMutex.RUnlock()
+	argsForCall := fake.withAPIKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithAPIKeyFile(arg1 string) {
+	fake.withAPIKeyFileMutex.Lock()
+	fake.withAPIKeyFileArgsForCall = append(fake.withAPIKeyFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAPIKeyFileStub
+	fake.recordInvocation("WithAPIKeyFile", []interface{}{arg1})
+	fake.withAPIKeyFileMutex.Unlock()
+	if stub != nil {
+		fake.WithAPIKeyFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAPIKeyFileCallCount() int {
+	fake.withAPIKeyFileMutex.RLock()
+	defer fake.withAPIKeyFileMutex.RUnlock()
+	return len(fake.withAPIKeyFileArgsForCall)
+}
+
+func (fake *FakeClient) WithAPIKeyFileCalls(stub func(string)) {
+	fake.withAPIKeyFileMutex.Lock()
+	defer fake.withAPIKeyFileMutex.Unlock()
+	fake.WithAPIKeyFileStub = stub
+}
+
+func (fake *FakeClient) WithAPIKeyFileArgsForCall(i int) string {
+	fake.withAPIKeyFileMutex.RLock()
+	defer fake.withAPIKeyFileMutex.RUnlock()
+	argsForCall := fake.withAPIKeyFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithAuthProvider(arg1 string) {
+	fake.withAuthProviderMutex.Lock()
+	fake.withAuthProviderArgsForCall = append(fake.withAuthProviderArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAuthProviderStub
+	fake.recordInvocation("WithAuthProvider", []interface{}{arg1})
+	fake.withAuthProviderMutex.Unlock()
+	if stub != nil {
+		fake.WithAuthProviderStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAuthProviderCallCount() int {
+	fake.withAuthProviderMutex.RLock()
+	defer fake.withAuthProviderMutex.RUnlock()
+	return len(fake.withAuthProviderArgsForCall)
+}
+
+func (fake *FakeClient) WithAuthProviderCalls(stub func(string)) {
+	fake.withAuthProviderMutex.Lock()
+	defer fake.withAuthProviderMutex.Unlock()
+	fake.WithAuthProviderStub = stub
+}
+
+func (fake *FakeClient) WithAuthProviderArgsForCall(i int) string {
+	fake.withAuthProviderMutex.RLock()
+	defer fake.withAuthProviderMutex.RUnlock()
+	argsForCall := fake.withAuthProviderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithCAFile(arg1 string) {
+	fake.withCAFileMutex.Lock()
+	fake.withCAFileArgsForCall = append(fake.withCAFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithCAFileStub
+	fake.recordInvocation("WithCAFile", []interface{}{arg1})
+	fake.withCAFileMutex.Unlock()
+	if stub != nil {
+		fake.WithCAFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithCAFileCallCount() int {
+	fake.withCAFileMutex.RLock()
+	defer fake.withCAFileMutex.RUnlock()
+	return len(fake.withCAFileArgsForCall)
+}
+
+func (fake *FakeClient) WithCAFileCalls(stub func(string)) {
+	fake.withCAFileMutex.Lock()
+	defer fake.withCAFileMutex.Unlock()
+	fake.WithCAFileStub = stub
+}
+
+func (fake *FakeClient) WithCAFileArgsForCall(i int) string {
+	fake.withCAFileMutex.RLock()
+	defer fake.withCAFileMutex.RUnlock()
+	argsForCall := fake.withCAFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithDescription(arg1 string) {
+	fake.withDescriptionMutex.Lock()
+	fake.withDescriptionArgsForCall = append(fake.withDescriptionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithDescriptionStub
+	fake.recordInvocation("WithDescription", []interface{}{arg1})
+	fake.withDescriptionMutex.Unlock()
+	if stub != nil {
+		fake.WithDescriptionStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithDescriptionCallCount() int {
+	fake.withDescriptionMutex.RLock()
+	defer fake.withDescriptionMutex.RUnlock()
+	return len(fake.withDescriptionArgsForCall)
+}
+
+func (fake *FakeClient) WithDescriptionCalls(stub func(string)) {
+	fake.withDescriptionMutex.Lock()
+	defer fake.withDescriptionMutex.Unlock()
+	fake.WithDescriptionStub = stub
+}
+
+func (fake *FakeClient) WithDescriptionArgsForCall(i int) string {
+	fake.withDescriptionMutex.RLock()
+	defer fake.withDescriptionMutex.RUnlock()
+	argsForCall := fake.withDescriptionArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeClient) WithPassword(arg1 string) {
 	fake.withPasswordMutex.Lock()
 	fake.withPasswordArgsForCall = append(fake.withPasswordArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.WithPasswordStub
 	fake.recordInvocation("WithPassword", []interface{}{arg1})
 	fake.withPasswordMutex.Unlock()
-	if fake.WithPasswordStub != nil {
+	if stub != nil {
 		fake.WithPasswordStub(arg1)
 	}
 }
@@ -141,14 +1019,111 @@ func (fake *FakeClient) WithPasswordArgsForCall(i int) string {
 	return argsForCall.arg1
 }
 
+func (fake *FakeClient) WithTTL(arg1 time.Duration) {
+	fake.withTTLMutex.Lock()
+	fake.withTTLArgsForCall = append(fake.withTTLArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.WithTTLStub
+	fake.recordInvocation("WithTTL", []interface{}{arg1})
+	fake.withTTLMutex.Unlock()
+	if stub != nil {
+		fake.WithTTLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTTLCallCount() int {
+	fake.withTTLMutex.RLock()
+	defer fake.withTTLMutex.RUnlock()
+	return len(fake.withTTLArgsForCall)
+}
+
+func (fake *FakeClient) WithTTLCalls(stub func(time.Duration)) {
+	fake.withTTLMutex.Lock()
+	defer fake.withTTLMutex.Unlock()
+	fake.WithTTLStub = stub
+}
+
+func (fake *FakeClient) WithTTLArgsForCall(i int) time.Duration {
+	fake.withTTLMutex.RLock()
+	defer fake.withTTLMutex.RUnlock()
+	argsForCall := fake.withTTLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithTimeout(arg1 time.Duration) {
+	fake.withTimeoutMutex.Lock()
+	fake.withTimeoutArgsForCall = append(fake.withTimeoutArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.WithTimeoutStub
+	fake.recordInvocation("WithTimeout", []interface{}{arg1})
+	fake.withTimeoutMutex.Unlock()
+	if stub != nil {
+		fake.WithTimeoutStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTimeoutCallCount() int {
+	fake.withTimeoutMutex.RLock()
+	defer fake.withTimeoutMutex.RUnlock()
+	return len(fake.withTimeoutArgsForCall)
+}
+
+func (fake *FakeClient) WithTimeoutCalls(stub func(time.Duration)) {
+	fake.withTimeoutMutex.Lock()
+	defer fake.withTimeoutMutex.Unlock()
+	fake.WithTimeoutStub = stub
+}
+
+func (fake *FakeClient) WithTimeoutArgsForCall(i int) time.Duration {
+	fake.withTimeoutMutex.RLock()
+	defer fake.withTimeoutMutex.RUnlock()
+	argsForCall := fake.withTimeoutArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithTokensFile(arg1 string) {
+	fake.withTokensFileMutex.Lock()
+	fake.withTokensFileArgsForCall = append(fake.withTokensFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithTokensFileStub
+	fake.recordInvocation("WithTokensFile", []interface{}{arg1})
+	fake.withTokensFileMutex.Unlock()
+	if stub != nil {
+		fake.WithTokensFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTokensFileCallCount() int {
+	fake.withTokensFileMutex.RLock()
+	defer fake.withTokensFileMutex.RUnlock()
+	return len(fake.withTokensFileArgsForCall)
+}
+
+func (fake *FakeClient) WithTokensFileCalls(stub func(string)) {
+	fake.withTokensFileMutex.Lock()
+	defer fake.withTokensFileMutex.Unlock()
+	fake.WithTokensFileStub = stub
+}
+
+func (fake *FakeClient) WithTokensFileArgsForCall(i int) string {
+	fake.withTokensFileMutex.RLock()
+	defer fake.withTokensFileMutex.RUnlock()
+	argsForCall := fake.withTokensFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeClient) WithTransport(arg1 *http.Transport) {
 	fake.withTransportMutex.Lock()
 	fake.withTransportArgsForCall = append(fake.withTransportArgsForCall, struct {
 		arg1 *http.Transport
 	}{arg1})
+	stub := fake.WithTransportStub
 	fake.recordInvocation("WithTransport", []interface{}{arg1})
 	fake.withTransportMutex.Unlock()
-	if fake.WithTransportStub != nil {
+	if stub != nil {
 		fake.WithTransportStub(arg1)
 	}
 }
@@ -177,9 +1152,10 @@ func (fake *FakeClient) WithURL(arg1 string) {
 	fake.withURLArgsForCall = append(fake.withURLArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.WithURLStub
 	fake.recordInvocation("WithURL", []interface{}{arg1})
 	fake.withURLMutex.Unlock()
-	if fake.WithURLStub != nil {
+	if stub != nil {
 		fake.WithURLStub(arg1)
 	}
 }
@@ -208,9 +1184,10 @@ func (fake *FakeClient) WithUsername(arg1 string) {
 	fake.withUsernameArgsForCall = append(fake.withUsernameArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.WithUsernameStub
 	fake.recordInvocation("WithUsername", []interface{}{arg1})
 	fake.withUsernameMutex.Unlock()
-	if fake.WithUsernameStub != nil {
+	if stub != nil {
 		fake.WithUsernameStub(arg1)
 	}
 }
@@ -237,10 +1214,44 @@ func (fake *FakeClient) WithUsernameArgsForCall(i int) string {
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.cADataMutex.RLock()
+	defer fake.cADataMutex.RUnlock()
+	fake.checkAuthProviderMutex.RLock()
+	defer fake.checkAuthProviderMutex.RUnlock()
+	fake.clustersMutex.RLock()
+	defer fake.clustersMutex.RUnlock()
+	fake.deleteCreatedTokensMutex.RLock()
+	defer fake.deleteCreatedTokensMutex.RUnlock()
+	fake.deleteTokenMutex.RLock()
+	defer fake.deleteTokenMutex.RUnlock()
+	fake.newClusterTokenMutex.RLock()
+	defer fake.newClusterTokenMutex.RUnlock()
 	fake.newTokenMutex.RLock()
 	defer fake.newTokenMutex.RUnlock()
+	fake.serverURLMutex.RLock()
+	defer fake.serverURLMutex.RUnlock()
+	fake.tokenMutex.RLock()
+	defer fake.tokenMutex.RUnlock()
+	fake.tokensMutex.RLock()
+	defer fake.tokensMutex.RUnlock()
+	fake.withAPIKeyMutex.RLock()
+	defer fake.withAPIKeyMutex.RUnlock()
+	fake.withAPIKeyFileMutex.RLock()
+	defer fake.withAPIKeyFileMutex.RUnlock()
+	fake.withAuthProviderMutex.RLock()
+	defer fake.withAuthProviderMutex.RUnlock()
+	fake.withCAFileMutex.RLock()
+	defer fake.withCAFileMutex.RUnlock()
+	fake.withDescriptionMutex.RLock()
+	defer fake.withDescriptionMutex.RUnlock()
 	fake.withPasswordMutex.RLock()
 	defer fake.withPasswordMutex.RUnlock()
+	fake.withTTLMutex.RLock()
+	defer fake.withTTLMutex.RUnlock()
+	fake.withTimeoutMutex.RLock()
+	defer fake.withTimeoutMutex.RUnlock()
+	fake.withTokensFileMutex.RLock()
+	defer fake.withTokensFileMutex.RUnlock()
 	fake.withTransportMutex.RLock()
 	defer fake.withTransportMutex.RUnlock()
 	fake.withURLMutex.RLock()
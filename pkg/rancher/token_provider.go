@@ -0,0 +1,232 @@
+package rancher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+const (
+	paramCluster = "cluster"
+	// DefaultMaxCacheAge is how long tokens that never expire are cached.
+	DefaultMaxCacheAge = 12 * time.Hour
+	// DefaultValidationInterval is how often cached tokens are checked
+	// against Rancher.
+	DefaultValidationInterval = 5 * time.Minute
+	// clustersCacheAge is how long discovered clusters are cached.
+	clustersCacheAge = 1 * time.Minute
+)
+
+// clusterIDRegexp matches Rancher cluster IDs such as c-m7v2x or local.
+var clusterIDRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// TokenProviderOptions configure the token provider of a Client.
+type TokenProviderOptions struct {
+	// RevokeOnShutdown deletes every token the client created when arcade
+	// shuts down.
+	RevokeOnShutdown bool
+	// MaxCacheAge is how long tokens that never expire are cached. They
+	// are not cached if it is zero.
+	MaxCacheAge time.Duration
+	// ValidationInterval is how often cached tokens are checked against
+	// Rancher, so tokens that were deleted, disabled or expired early are
+	// replaced. They are not checked if it is zero.
+	ValidationInterval time.Duration
+}
+
+// NewTokenProvider returns a provider.TokenProvider that creates
+// kubeconfig tokens with c. Replaced tokens are deleted from Rancher.
+func NewTokenProvider(c Client, opts TokenProviderOptions) provider.TokenProvider {
+	return &tokenProvider{
+		c:    c,
+		opts: opts,
+	}
+}
+
+type tokenProvider struct {
+	c    Client
+	opts TokenProviderOptions
+
+	mux        sync.Mutex
+	clusters   []provider.Cluster
+	clustersAt time.Time
+}
+
+func (tp *tokenProvider) NewToken(ctx context.Context) (provider.Token, error) {
+	k, err := tp.c.NewToken(ctx)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	return tp.cachedToken(k), nil
+}
+
+// Params returns the cluster query parameter, which requests a token
+// scoped to a downstream cluster, for example cluster=c-m7v2x.
+func (tp *tokenProvider) Params() []string {
+	return []string{paramCluster}
+}
+
+func (tp *tokenProvider) NewTokenWithParams(ctx context.Context, params url.Values) (provider.Token, error) {
+	clusterID := params.Get(paramCluster)
+	if clusterID == "" {
+		return tp.NewToken(ctx)
+	}
+
+	if !clusterIDRegexp.MatchString(clusterID) {
+		return provider.Token{}, fmt.Errorf("%w: invalid cluster: %s", provider.ErrInvalidParams, clusterID)
+	}
+
+	k, err := tp.c.NewClusterToken(ctx, clusterID)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	return tp.cachedToken(k), nil
+}
+
+// Clusters returns the downstream clusters the configured user can access.
+// Their server is the Rancher proxy to the cluster, which is trusted with
+// the CA bundle of the Rancher server, and their tokens are scoped to the
+// cluster. Clusters are cached for a minute.
+func (tp *tokenProvider) Clusters(ctx context.Context) ([]provider.Cluster, error) {
+	tp.mux.Lock()
+	defer tp.mux.Unlock()
+
+	if tp.clusters != nil && time.Since(tp.clustersAt) < clustersCacheAge {
+		return tp.clusters, nil
+	}
+
+	clusters, err := tp.c.Clusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := tp.c.CAData()
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := make([]provider.Cluster, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		server, err := tp.c.ServerURL(cluster.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		discovered = append(discovered, provider.Cluster{
+			Name:                     cluster.Name,
+			ID:                       cluster.ID,
+			Server:                   server,
+			CertificateAuthorityData: ca,
+			TokenParams: url.Values{
+				paramCluster: []string{cluster.ID},
+			},
+			Metadata: map[string]string{
+				"state":       cluster.State,
+				"apiEndpoint": cluster.APIEndpoint,
+				"caCert":      cluster.CACert,
+			},
+		})
+	}
+
+	tp.clusters = discovered
+	tp.clustersAt = time.Now()
+
+	return discovered, nil
+}
+
+// Revoke deletes t from Rancher.
+func (tp *tokenProvider) Revoke(ctx context.Context, t provider.Token) error {
+	if t.ID == "" {
+		return nil
+	}
+
+	return tp.c.DeleteToken(ctx, t.ID)
+}
+
+func (tp *tokenProvider) ValidationInterval() time.Duration {
+	return tp.opts.ValidationInterval
+}
+
+// Validate returns false if t was deleted from Rancher, or has been
+// disabled or has expired there.
+func (tp *tokenProvider) Validate(ctx context.Context, t provider.Token) (bool, error) {
+	if t.ID == "" {
+		return true, nil
+	}
+
+	k, err := tp.c.Token(ctx, t.ID)
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return k.Enabled && !k.Expired, nil
+}
+
+// Tokens returns the kubeconfig tokens of the configured user.
+func (tp *tokenProvider) Tokens(ctx context.Context) ([]provider.Token, error) {
+	kubeconfigTokens, err := tp.c.Tokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]provider.Token, 0, len(kubeconfigTokens))
+	for _, k := range kubeconfigTokens {
+		tokens = append(tokens, token(k))
+	}
+
+	return tokens, nil
+}
+
+// Close deletes every token the client created if RevokeOnShutdown is set.
+func (tp *tokenProvider) Close(ctx context.Context) error {
+	if !tp.opts.RevokeOnShutdown {
+		return nil
+	}
+
+	return tp.c.DeleteCreatedTokens(ctx)
+}
+
+// cachedToken returns the token of k, cached for MaxCacheAge if k never
+// expires.
+func (tp *tokenProvider) cachedToken(k KubeconfigToken) provider.Token {
+	t := token(k)
+
+	if !k.Expires() && tp.opts.MaxCacheAge > 0 {
+		t.ExpiresAt = time.Now().In(time.UTC).Add(tp.opts.MaxCacheAge)
+		t.Metadata["expires"] = "false"
+	}
+
+	return t
+}
+
+func token(k KubeconfigToken) provider.Token {
+	t := provider.Token{
+		Token:     k.Token,
+		ExpiresAt: k.ExpiresAt,
+		IssuedAt:  k.Created,
+		ID:        k.ID,
+		Metadata: map[string]string{
+			"authProvider": k.AuthProvider,
+			"userId":       k.UserID,
+		},
+	}
+
+	if k.ClusterID != "" {
+		t.Metadata["clusterId"] = k.ClusterID
+	}
+
+	return t
+}
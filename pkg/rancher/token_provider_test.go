@@ -0,0 +1,287 @@
+package rancher_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/homedepot/arcade/pkg/provider"
+	. "github.com/homedepot/arcade/pkg/rancher"
+	"github.com/homedepot/arcade/pkg/rancher/rancherfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenProvider", func() {
+	var (
+		fakeClient *rancherfakes.FakeClient
+		tp         provider.ParamsTokenProvider
+		params     url.Values
+		t          provider.Token
+		err        error
+	)
+
+	BeforeEach(func() {
+		fakeClient = &rancherfakes.FakeClient{}
+		fakeClient.NewTokenReturns(KubeconfigToken{Token: "fake-rancher-token"}, nil)
+		fakeClient.NewClusterTokenReturns(KubeconfigToken{Token: "fake-cluster-token", ClusterID: "c-abc12"}, nil)
+		tp = NewTokenProvider(fakeClient, TokenProviderOptions{}).(provider.ParamsTokenProvider)
+		params = url.Values{}
+	})
+
+	Describe("#NewTokenWithParams", func() {
+		JustBeforeEach(func() {
+			t, err = tp.NewTokenWithParams(context.Background(), params)
+		})
+
+		When("no cluster is requested", func() {
+			It("returns a global token", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-rancher-token"))
+				Expect(t.Metadata).ToNot(HaveKey("clusterId"))
+				Expect(fakeClient.NewClusterTokenCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the cluster is invalid", func() {
+			BeforeEach(func() {
+				params.Set("cluster", "c-abc12/../local")
+			})
+
+			It("returns an invalid params error", func() {
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeTrue())
+			})
+		})
+
+		When("getting the cluster token fails", func() {
+			BeforeEach(func() {
+				params.Set("cluster", "c-abc12")
+				fakeClient.NewClusterTokenReturns(KubeconfigToken{}, errors.New("error getting token"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Is(err, provider.ErrInvalidParams)).To(BeFalse())
+			})
+		})
+
+		When("a cluster is requested", func() {
+			BeforeEach(func() {
+				params.Set("cluster", "c-abc12")
+			})
+
+			It("returns a token scoped to the cluster", func() {
+				Expect(err).To(BeNil())
+				Expect(t.Token).To(Equal("fake-cluster-token"))
+				Expect(t.Metadata).To(HaveKeyWithValue("clusterId", "c-abc12"))
+				_, clusterID := fakeClient.NewClusterTokenArgsForCall(0)
+				Expect(clusterID).To(Equal("c-abc12"))
+			})
+		})
+	})
+
+	Describe("#Clusters", func() {
+		var clusters []provider.Cluster
+
+		JustBeforeEach(func() {
+			clusters, err = tp.(provider.ClusterDiscoverer).Clusters(context.Background())
+		})
+
+		When("listing clusters fails", func() {
+			BeforeEach(func() {
+				fakeClient.ClustersReturns(nil, errors.New("error listing clusters"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("reading the CA bundle fails", func() {
+			BeforeEach(func() {
+				fakeClient.CADataReturns(nil, errors.New("error reading CA file"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error reading CA file"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				fakeClient.CADataReturns([]byte("rancher-ca"), nil)
+				fakeClient.ClustersReturns([]Cluster{
+					{ID: "c-abc12", Name: "prod-east", State: "active", APIEndpoint: "https://10.0.0.1:6443", CACert: "Y2E="},
+				}, nil)
+				fakeClient.ServerURLReturns("https://rancher.example.com/k8s/clusters/c-abc12", nil)
+			})
+
+			It("returns the clusters proxied by rancher", func() {
+				Expect(err).To(BeNil())
+				Expect(clusters).To(HaveLen(1))
+				Expect(clusters[0].Name).To(Equal("prod-east"))
+				Expect(clusters[0].ID).To(Equal("c-abc12"))
+				Expect(clusters[0].Server).To(Equal("https://rancher.example.com/k8s/clusters/c-abc12"))
+				Expect(clusters[0].Metadata).To(HaveKeyWithValue("state", "active"))
+				Expect(clusters[0].Metadata).To(HaveKeyWithValue("apiEndpoint", "https://10.0.0.1:6443"))
+				Expect(fakeClient.ServerURLArgsForCall(0)).To(Equal("c-abc12"))
+			})
+
+			It("trusts the rancher proxy with the CA bundle of rancher", func() {
+				Expect(clusters[0].CertificateAuthorityData).To(Equal([]byte("rancher-ca")))
+			})
+
+			It("scopes their tokens to the cluster", func() {
+				Expect(clusters[0].TokenParams).To(Equal(url.Values{"cluster": []string{"c-abc12"}}))
+			})
+
+			It("caches them", func() {
+				clusters, err = tp.(provider.ClusterDiscoverer).Clusters(context.Background())
+				Expect(err).To(BeNil())
+				Expect(clusters).To(HaveLen(1))
+				Expect(fakeClient.ClustersCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("#Revoke", func() {
+		It("deletes the token", func() {
+			err = tp.(provider.Revoker).Revoke(context.Background(), provider.Token{ID: "kubeconfig-u-stale1"})
+			Expect(err).To(BeNil())
+			_, id := fakeClient.DeleteTokenArgsForCall(0)
+			Expect(id).To(Equal("kubeconfig-u-stale1"))
+		})
+
+		It("ignores tokens without an ID", func() {
+			err = tp.(provider.Revoker).Revoke(context.Background(), provider.Token{})
+			Expect(err).To(BeNil())
+			Expect(fakeClient.DeleteTokenCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("#Tokens", func() {
+		It("returns the tokens of the user", func() {
+			fakeClient.TokensReturns([]KubeconfigToken{{ID: "kubeconfig-u-stale1", ClusterID: "c-abc12"}}, nil)
+			tokens, err := tp.(provider.TokenLister).Tokens(context.Background())
+			Expect(err).To(BeNil())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].ID).To(Equal("kubeconfig-u-stale1"))
+			Expect(tokens[0].Metadata).To(HaveKeyWithValue("clusterId", "c-abc12"))
+		})
+	})
+
+	Describe("tokens that never expire", func() {
+		It("are not cached by default", func() {
+			t, err = tp.NewToken(context.Background())
+			Expect(err).To(BeNil())
+			Expect(t.ExpiresAt.IsZero()).To(BeTrue())
+		})
+
+		It("are cached for the max cache age", func() {
+			tp = NewTokenProvider(fakeClient, TokenProviderOptions{MaxCacheAge: time.Hour}).(provider.ParamsTokenProvider)
+			t, err = tp.NewToken(context.Background())
+			Expect(err).To(BeNil())
+			Expect(t.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			Expect(t.Metadata).To(HaveKeyWithValue("expires", "false"))
+		})
+
+		It("does not change the expiry of other tokens", func() {
+			expiresAt := time.Now().Add(10 * time.Minute)
+			fakeClient.NewTokenReturns(KubeconfigToken{Token: "fake-rancher-token", ExpiresAt: expiresAt}, nil)
+			tp = NewTokenProvider(fakeClient, TokenProviderOptions{MaxCacheAge: time.Hour}).(provider.ParamsTokenProvider)
+			t, err = tp.NewToken(context.Background())
+			Expect(err).To(BeNil())
+			Expect(t.ExpiresAt).To(Equal(expiresAt))
+			Expect(t.Metadata).ToNot(HaveKey("expires"))
+		})
+	})
+
+	Describe("#Validate", func() {
+		var (
+			validator provider.TokenValidator
+			valid     bool
+		)
+
+		BeforeEach(func() {
+			validator = NewTokenProvider(fakeClient, TokenProviderOptions{ValidationInterval: time.Minute}).(provider.TokenValidator)
+			fakeClient.TokenReturns(KubeconfigToken{ID: "kubeconfig-u-abc12", Enabled: true}, nil)
+		})
+
+		JustBeforeEach(func() {
+			valid, err = validator.Validate(context.Background(), provider.Token{ID: "kubeconfig-u-abc12"})
+		})
+
+		It("returns the validation interval", func() {
+			Expect(validator.ValidationInterval()).To(Equal(time.Minute))
+		})
+
+		When("getting the token fails", func() {
+			BeforeEach(func() {
+				fakeClient.TokenReturns(KubeconfigToken{}, errors.New("error getting token"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the token was deleted", func() {
+			BeforeEach(func() {
+				fakeClient.TokenReturns(KubeconfigToken{}, fmt.Errorf("%w: kubeconfig-u-abc12", ErrTokenNotFound))
+			})
+
+			It("is not valid", func() {
+				Expect(err).To(BeNil())
+				Expect(valid).To(BeFalse())
+			})
+		})
+
+		When("the token was disabled", func() {
+			BeforeEach(func() {
+				fakeClient.TokenReturns(KubeconfigToken{ID: "kubeconfig-u-abc12", Enabled: false}, nil)
+			})
+
+			It("is not valid", func() {
+				Expect(err).To(BeNil())
+				Expect(valid).To(BeFalse())
+			})
+		})
+
+		When("the token has expired", func() {
+			BeforeEach(func() {
+				fakeClient.TokenReturns(KubeconfigToken{ID: "kubeconfig-u-abc12", Enabled: true, Expired: true}, nil)
+			})
+
+			It("is not valid", func() {
+				Expect(err).To(BeNil())
+				Expect(valid).To(BeFalse())
+			})
+		})
+
+		When("the token is valid", func() {
+			It("is valid", func() {
+				Expect(err).To(BeNil())
+				Expect(valid).To(BeTrue())
+				_, id := fakeClient.TokenArgsForCall(0)
+				Expect(id).To(Equal("kubeconfig-u-abc12"))
+			})
+		})
+	})
+
+	Describe("#Close", func() {
+		It("keeps the created tokens by default", func() {
+			Expect(tp.(provider.Closer).Close(context.Background())).To(Succeed())
+			Expect(fakeClient.DeleteCreatedTokensCallCount()).To(Equal(0))
+		})
+
+		It("deletes the created tokens when configured to", func() {
+			tp = NewTokenProvider(fakeClient, TokenProviderOptions{RevokeOnShutdown: true}).(provider.ParamsTokenProvider)
+			Expect(tp.(provider.Closer).Close(context.Background())).To(Succeed())
+			Expect(fakeClient.DeleteCreatedTokensCallCount()).To(Equal(1))
+		})
+	})
+})
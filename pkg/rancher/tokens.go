@@ -0,0 +1,212 @@
+package rancher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrTokenNotFound is returned for tokens that Rancher has deleted.
+	ErrTokenNotFound     = errors.New("token not found")
+	errGetTokenFormat    = "error getting token %s: %s"
+	errListTokensFormat  = "error listing tokens: %s"
+	errDeleteTokenFormat = "error deleting token %s: %s"
+)
+
+type tokenCollection struct {
+	Data       []KubeconfigToken `json:"data"`
+	Pagination struct {
+		Next string `json:"next"`
+	} `json:"pagination"`
+}
+
+// Tokens returns the tokens the client created and has not deleted,
+// including the ones read from its tokens file, except the token it calls
+// the Rancher API with. Tokens created by other
+// arcade instances or downloaded from the Rancher UI are never returned.
+func (c *client) Tokens(ctx context.Context) ([]KubeconfigToken, error) {
+	u, err := c.apiURL("/v3/tokens")
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []KubeconfigToken{}
+
+	for u != "" {
+		collection := tokenCollection{}
+
+		err = c.do(ctx, http.MethodGet, u, nil, http.StatusOK, &collection, errListTokensFormat)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, k := range collection.Data {
+			if !k.Current && c.tracked(k.ID) {
+				tokens = append(tokens, k)
+			}
+		}
+
+		u = collection.Pagination.Next
+	}
+
+	return tokens, nil
+}
+
+// Token returns the token with the given ID, without its secret.
+// ErrTokenNotFound is returned if it no longer exists.
+func (c *client) Token(ctx context.Context, id string) (KubeconfigToken, error) {
+	k := KubeconfigToken{}
+
+	u, err := c.apiURL("/v3/tokens/" + id)
+	if err != nil {
+		return k, err
+	}
+
+	err = c.do(ctx, http.MethodGet, u, nil, http.StatusOK, &k, errGetTokenFormat, id)
+	if err != nil {
+		var se statusError
+		if errors.As(err, &se) && se.code == http.StatusNotFound {
+			return k, fmt.Errorf("%w: %s", ErrTokenNotFound, id)
+		}
+
+		return k, err
+	}
+
+	return k, nil
+}
+
+// DeleteToken deletes the token with the given ID. Tokens that no longer
+// exist are ignored.
+func (c *client) DeleteToken(ctx context.Context, id string) error {
+	// IDs are the last element of a token's self link, /v3/tokens/<id>.
+	u, err := c.apiURL("/v3/tokens/" + id)
+	if err != nil {
+		return err
+	}
+
+	err = c.do(ctx, http.MethodDelete, u, nil, http.StatusNoContent, nil, errDeleteTokenFormat, id)
+	if err != nil {
+		return err
+	}
+
+	c.untrack(id)
+
+	return nil
+}
+
+// DeleteCreatedTokens deletes every token the client created and has not
+// deleted yet, including the token it calls the Rancher API with, which is
+// deleted last.
+func (c *client) DeleteCreatedTokens(ctx context.Context) error {
+	c.mux.Lock()
+	sessionID := c.session.ID
+	c.mux.Unlock()
+
+	c.createdMux.Lock()
+	ids := make([]string, 0, len(c.created))
+
+	for id := range c.created {
+		if id != sessionID {
+			ids = append(ids, id)
+		}
+	}
+	c.createdMux.Unlock()
+
+	errs := []string{}
+
+	for _, id := range ids {
+		if err := c.DeleteToken(ctx, id); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if sessionID != "" {
+		if err := c.DeleteToken(ctx, sessionID); err != nil {
+			errs = append(errs, err.Error())
+		}
+
+		c.resetSession()
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// WithTokensFile sets a file the IDs of the tokens the client created are
+// kept in, so tokens created before arcade restarted or crashed can still
+// be listed and deleted. The IDs already in the file are read now.
+func (c *client) WithTokensFile(tokensFile string) {
+	c.createdMux.Lock()
+	defer c.createdMux.Unlock()
+
+	c.tokensFile = tokensFile
+
+	b, err := ioutil.ReadFile(tokensFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("error reading tokens file %s: %s", tokensFile, err.Error())
+		}
+
+		return
+	}
+
+	for _, id := range strings.Fields(string(b)) {
+		c.created[id] = struct{}{}
+	}
+}
+
+// writeTokensFile writes the IDs of the tokens the client created to the
+// tokens file, one per line. c.createdMux must be held.
+func (c *client) writeTokensFile() {
+	if c.tokensFile == "" {
+		return
+	}
+
+	ids := make([]string, 0, len(c.created))
+	for id := range c.created {
+		ids = append(ids, id+"\n")
+	}
+
+	sort.Strings(ids)
+
+	err := writeFile(c.tokensFile, []byte(strings.Join(ids, "")))
+	if err != nil {
+		log.Printf("error writing tokens file %s: %s", c.tokensFile, err.Error())
+	}
+}
+
+// writeFile replaces the file at path with b, so a crash never leaves it
+// half written.
+func writeFile(path string, b []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Chmod(0600)
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,137 @@
+package rancher
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	errNoCertificatesFormat = "no certificates found in %s"
+)
+
+// WithCAFile sets a PEM bundle of the CAs that sign the certificate of the
+// Rancher server. The bundle is read again whenever the file changes.
+func (c *client) WithCAFile(caFile string) {
+	c.caFile = caFile
+	c.updateTransport()
+}
+
+// CAData returns the PEM bundle of the CA file, or nil if none is set and
+// the Rancher server is trusted by the system roots.
+func (c *client) CAData() ([]byte, error) {
+	if c.caFile == "" {
+		return nil, nil
+	}
+
+	return ioutil.ReadFile(c.caFile)
+}
+
+// WithTimeout sets the time limit of each request to Rancher.
+func (c *client) WithTimeout(timeout time.Duration) {
+	c.c.Timeout = timeout
+}
+
+func (c *client) WithTransport(transport *http.Transport) {
+	c.transport = transport
+	c.updateTransport()
+}
+
+// updateTransport sets the round tripper of the client from its transport
+// and CA file.
+func (c *client) updateTransport() {
+	if c.caFile == "" {
+		if c.transport == nil {
+			c.c.Transport = nil
+		} else {
+			c.c.Transport = c.transport
+		}
+
+		return
+	}
+
+	base := c.transport
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+
+	c.c.Transport = &caReloadingTransport{
+		base:   base,
+		caFile: c.caFile,
+	}
+}
+
+// caReloadingTransport is a clone of base that trusts the CAs in caFile.
+// The clone is replaced when the content of the file changes.
+type caReloadingTransport struct {
+	mux     sync.Mutex
+	base    *http.Transport
+	caFile  string
+	ca      []byte
+	current *http.Transport
+}
+
+func (t *caReloadingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport, err := t.transport()
+	if err != nil {
+		return nil, err
+	}
+
+	return transport.RoundTrip(req)
+}
+
+// transport returns the transport trusting the current content of the CA
+// file. If the file cannot be read the last transport is kept.
+func (t *caReloadingTransport) transport() (*http.Transport, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	b, err := ioutil.ReadFile(t.caFile)
+	if err != nil {
+		if t.current == nil {
+			return nil, err
+		}
+
+		log.Printf("error reading CA file %s, using the last CAs: %s", t.caFile, err.Error())
+
+		return t.current, nil
+	}
+
+	if t.current != nil && bytes.Equal(b, t.ca) {
+		return t.current, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		err = fmt.Errorf(errNoCertificatesFormat, t.caFile)
+		if t.current == nil {
+			return nil, err
+		}
+
+		log.Printf("%s, using the last CAs", err.Error())
+
+		return t.current, nil
+	}
+
+	transport := t.base.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+
+	transport.TLSClientConfig.RootCAs = pool
+
+	if t.current != nil {
+		t.current.CloseIdleConnections()
+	}
+
+	t.ca = b
+	t.current = transport
+
+	return transport, nil
+}
@@ -0,0 +1,59 @@
+package rancher
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ttlSkew is how much longer than requested a token may live, to allow for
+// the clocks of arcade and Rancher and rounding of its expiry.
+const ttlSkew = 1 * time.Minute
+
+var (
+	errTTLFormat = "error getting token: rancher granted a ttl of %s, requested %s"
+)
+
+// checkTTL checks that k does not live longer than the TTL the client
+// requested. Such tokens are deleted so they cannot outlive the rotation
+// policy. Tokens that live shorter than requested, because Rancher caps
+// their TTL, are accepted.
+func (c *client) checkTTL(ctx context.Context, k KubeconfigToken) error {
+	if c.ttl == 0 {
+		return nil
+	}
+
+	granted := time.Duration(k.TTL) * time.Millisecond
+	if !k.ExpiresAt.IsZero() && !k.Created.IsZero() && k.ExpiresAt.Sub(k.Created) > granted {
+		granted = k.ExpiresAt.Sub(k.Created)
+	}
+
+	if k.TTL == 0 || granted > c.ttl+ttlSkew {
+		c.deleteOwnToken(ctx, k)
+		return fmt.Errorf(errTTLFormat, granted, c.ttl)
+	}
+
+	if granted < c.ttl-ttlSkew {
+		log.Printf("rancher granted token %s a ttl of %s, requested %s", k.ID, granted, c.ttl)
+	}
+
+	return nil
+}
+
+// deleteOwnToken deletes k with itself, so no other token is needed.
+func (c *client) deleteOwnToken(ctx context.Context, k KubeconfigToken) {
+	if k.ID == "" || k.Token == "" {
+		return
+	}
+
+	u, err := c.apiURL("/v3/tokens/" + k.ID)
+	if err == nil {
+		err = c.doWithToken(ctx, k.Token, http.MethodDelete, u, nil, http.StatusNoContent, nil, errDeleteTokenFormat, k.ID)
+	}
+
+	if err != nil {
+		log.Printf("error deleting token %s: %s", k.ID, err.Error())
+	}
+}
@@ -0,0 +1,298 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AuthMethodKubernetes logs in with a service account token.
+	AuthMethodKubernetes = "kubernetes"
+	// AuthMethodAppRole logs in with a role ID and secret ID.
+	AuthMethodAppRole = "approle"
+	// DefaultJWTFile is the in-cluster service account token used for
+	// Kubernetes auth.
+	DefaultJWTFile   = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	defaultMountPath = "kubernetes"
+)
+
+var (
+	errNotFoundFormat     = "error getting token: %s"
+	errLoginFormat        = "error logging in to vault: %s"
+	errAuthMethodFormat   = "error logging in to vault: unsupported auth method %s"
+	errRevokeFormat       = "error revoking lease: %s"
+	credentialsPathFormat = "/v1/%s/creds/%s"
+	loginPathFormat       = "/v1/auth/%s/login"
+	revokeLeasePath       = "/v1/sys/leases/revoke"
+	vaultTokenHeader      = "X-Vault-Token"
+	// clientTokenSkew is how long before its lease ends the Vault client
+	// token is considered expired.
+	clientTokenSkew = 30 * time.Second
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	NewToken(context.Context) (Credentials, error)
+	RevokeLease(context.Context, string) error
+	WithURL(string)
+	WithAuthMethod(string)
+	WithAuthMountPath(string)
+	WithAuthRole(string)
+	WithJWTFile(string)
+	WithRoleID(string)
+	WithSecretID(string)
+	WithMountPath(string)
+	WithRole(string)
+	WithKubernetesNamespace(string)
+	WithTTL(string)
+	WithTransport(*http.Transport)
+}
+
+func NewClient() Client {
+	return &client{
+		authMethod: AuthMethodKubernetes,
+		jwtFile:    DefaultJWTFile,
+		mountPath:  defaultMountPath,
+		c:          &http.Client{},
+	}
+}
+
+type client struct {
+	url                 string
+	authMethod          string
+	authMountPath       string
+	authRole            string
+	jwtFile             string
+	roleID              string
+	secretID            string
+	mountPath           string
+	role                string
+	kubernetesNamespace string
+	ttl                 string
+	c                   *http.Client
+	mux                 sync.Mutex
+	clientToken         string
+	clientTokenExpiry   time.Time
+}
+
+func (c *client) WithURL(url string) {
+	c.url = strings.TrimSuffix(url, "/")
+}
+
+func (c *client) WithAuthMethod(authMethod string) {
+	c.authMethod = authMethod
+}
+
+// WithAuthMountPath sets the path the auth method is mounted at. It
+// defaults to the name of the auth method.
+func (c *client) WithAuthMountPath(authMountPath string) {
+	c.authMountPath = authMountPath
+}
+
+func (c *client) WithAuthRole(authRole string) {
+	c.authRole = authRole
+}
+
+func (c *client) WithJWTFile(jwtFile string) {
+	c.jwtFile = jwtFile
+}
+
+func (c *client) WithRoleID(roleID string) {
+	c.roleID = roleID
+}
+
+func (c *client) WithSecretID(secretID string) {
+	c.secretID = secretID
+}
+
+// WithMountPath sets the path the Kubernetes secrets engine is mounted at.
+func (c *client) WithMountPath(mountPath string) {
+	c.mountPath = mountPath
+}
+
+func (c *client) WithRole(role string) {
+	c.role = role
+}
+
+func (c *client) WithKubernetesNamespace(kubernetesNamespace string) {
+	c.kubernetesNamespace = kubernetesNamespace
+}
+
+func (c *client) WithTTL(ttl string) {
+	c.ttl = ttl
+}
+
+func (c *client) WithTransport(transport *http.Transport) {
+	c.c.Transport = transport
+}
+
+// NewToken generates a service account token from the Kubernetes secrets
+// engine, logging in to Vault first if there is no valid client token.
+func (c *client) NewToken(ctx context.Context) (Credentials, error) {
+	creds := Credentials{}
+
+	clientToken, err := c.login(ctx)
+	if err != nil {
+		return creds, err
+	}
+
+	data := map[string]string{
+		"kubernetes_namespace": c.kubernetesNamespace,
+	}
+
+	if c.ttl != "" {
+		data["ttl"] = c.ttl
+	}
+
+	res, err := c.do(ctx, http.MethodPost, fmt.Sprintf(credentialsPathFormat, c.mountPath, c.role), clientToken, data)
+	if err != nil {
+		return creds, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+
+		// Client tokens that do not expire can still be revoked, so log in
+		// again on the next call.
+		if res.StatusCode == http.StatusForbidden {
+			c.resetClientToken()
+		}
+
+		return creds, fmt.Errorf(errNotFoundFormat, res.Status)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&creds)
+	if err != nil {
+		return creds, err
+	}
+
+	creds.ExpiresAt = time.Now().In(time.UTC).Add(time.Duration(creds.LeaseDuration) * time.Second)
+
+	return creds, nil
+}
+
+// RevokeLease revokes the lease of credentials that are no longer used,
+// which deletes the service account token in the target cluster.
+func (c *client) RevokeLease(ctx context.Context, leaseID string) error {
+	clientToken, err := c.login(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.do(ctx, http.MethodPut, revokeLeasePath, clientToken, map[string]string{"lease_id": leaseID})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return fmt.Errorf(errRevokeFormat, res.Status)
+	}
+
+	return nil
+}
+
+// login returns a cached Vault client token, or logs in with the
+// configured auth method when it has expired.
+func (c *client) login(ctx context.Context) (string, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	// A zero expiry is a client token that does not expire.
+	if c.clientToken != "" &&
+		(c.clientTokenExpiry.IsZero() || time.Now().Add(clientTokenSkew).Before(c.clientTokenExpiry)) {
+		return c.clientToken, nil
+	}
+
+	data := map[string]string{}
+
+	switch c.authMethod {
+	case AuthMethodKubernetes:
+		// Read the JWT on every login since it is rotated by the kubelet.
+		b, err := ioutil.ReadFile(c.jwtFile)
+		if err != nil {
+			return "", err
+		}
+
+		data["role"] = c.authRole
+		data["jwt"] = strings.TrimSpace(string(b))
+	case AuthMethodAppRole:
+		data["role_id"] = c.roleID
+		data["secret_id"] = c.secretID
+	default:
+		return "", fmt.Errorf(errAuthMethodFormat, c.authMethod)
+	}
+
+	authMountPath := c.authMountPath
+	if authMountPath == "" {
+		authMountPath = c.authMethod
+	}
+
+	res, err := c.do(ctx, http.MethodPost, fmt.Sprintf(loginPathFormat, authMountPath), "", data)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		return "", fmt.Errorf(errLoginFormat, res.Status)
+	}
+
+	lr := loginResponse{}
+
+	err = json.NewDecoder(res.Body).Decode(&lr)
+	if err != nil {
+		return "", err
+	}
+
+	c.clientToken = lr.Auth.ClientToken
+	c.clientTokenExpiry = time.Time{}
+
+	if lr.Auth.LeaseDuration > 0 {
+		c.clientTokenExpiry = time.Now().Add(time.Duration(lr.Auth.LeaseDuration) * time.Second)
+	}
+
+	return c.clientToken, nil
+}
+
+func (c *client) resetClientToken() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.clientToken = ""
+}
+
+func (c *client) do(ctx context.Context, method, path, clientToken string, data interface{}) (*http.Response, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, c.url+path, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/json")
+
+	if clientToken != "" {
+		req.Header.Add(vaultTokenHeader, clientToken)
+	}
+
+	return c.c.Do(req)
+}
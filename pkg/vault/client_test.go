@@ -0,0 +1,289 @@
+package vault_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/homedepot/arcade/pkg/vault"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server *ghttp.Server
+		client Client
+		dir    string
+		creds  Credentials
+		err    error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		dir, _ = ioutil.TempDir("", "arcade-vault")
+		jwtFile := filepath.Join(dir, "token")
+		_ = ioutil.WriteFile(jwtFile, []byte("test-jwt\n"), 0600)
+		client = NewClient()
+		client.WithURL(server.URL())
+		client.WithAuthRole("arcade")
+		client.WithJWTFile(jwtFile)
+		client.WithRole("arcade")
+		client.WithKubernetesNamespace("spinnaker")
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("#NewToken", func() {
+		JustBeforeEach(func() {
+			creds, err = client.NewToken(context.Background())
+		})
+
+		When("the server is not reachable", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the auth method is not supported", func() {
+			BeforeEach(func() {
+				client.WithAuthMethod("userpass")
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error logging in to vault: unsupported auth method userpass"))
+			})
+		})
+
+		When("the jwt file does not exist", func() {
+			BeforeEach(func() {
+				client.WithJWTFile(filepath.Join(dir, "missing"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("logging in fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusForbidden, nil),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error logging in to vault: 403 Forbidden"))
+			})
+		})
+
+		When("the login response is invalid", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{;'iuiuiu`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("invalid character ';' looking for beginning of object key string"))
+			})
+		})
+
+		When("generating credentials fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, payloadLogin),
+					ghttp.RespondWith(http.StatusBadRequest, nil),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error getting token: 400 Bad Request"))
+			})
+		})
+
+		When("the credentials response is invalid", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, payloadLogin),
+					ghttp.RespondWith(http.StatusOK, `{;'iuiuiu`),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("invalid character ';' looking for beginning of object key string"))
+			})
+		})
+
+		When("using approle auth", func() {
+			BeforeEach(func() {
+				client.WithAuthMethod("approle")
+				client.WithRoleID("test-role-id")
+				client.WithSecretID("test-secret-id")
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/auth/approle/login"),
+						ghttp.VerifyJSON(`{"role_id":"test-role-id","secret_id":"test-secret-id"}`),
+						ghttp.RespondWith(http.StatusOK, payloadLogin),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/kubernetes/creds/arcade"),
+						ghttp.RespondWith(http.StatusOK, payloadCredentials),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(creds.Data.ServiceAccountToken).To(HavePrefix("eyJhbGciOiJSUzI1NiIsImtpZCI6ImZha2UifQ."))
+			})
+		})
+
+		When("the mount paths and ttl are set", func() {
+			BeforeEach(func() {
+				client.WithAuthMountPath("k8s-prod")
+				client.WithMountPath("k8s-secrets")
+				client.WithTTL("10m")
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/auth/k8s-prod/login"),
+						ghttp.RespondWith(http.StatusOK, payloadLogin),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/k8s-secrets/creds/arcade"),
+						ghttp.VerifyJSON(`{"kubernetes_namespace":"spinnaker","ttl":"10m"}`),
+						ghttp.RespondWith(http.StatusOK, payloadCredentials),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(creds.LeaseID).To(Equal("kubernetes/creds/arcade/cjzmN7sSlbWYwT1TFKb7GqkE"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/auth/kubernetes/login"),
+						ghttp.VerifyJSON(`{"role":"arcade","jwt":"test-jwt"}`),
+						ghttp.RespondWith(http.StatusOK, payloadLogin),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/kubernetes/creds/arcade"),
+						ghttp.VerifyHeaderKV("x-vault-token", "hvs.fake-client-token"),
+						ghttp.VerifyJSON(`{"kubernetes_namespace":"spinnaker"}`),
+						ghttp.RespondWith(http.StatusOK, payloadCredentials),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+				Expect(creds.Data.ServiceAccountToken).To(HavePrefix("eyJhbGciOiJSUzI1NiIsImtpZCI6ImZha2UifQ."))
+				Expect(creds.LeaseID).To(Equal("kubernetes/creds/arcade/cjzmN7sSlbWYwT1TFKb7GqkE"))
+				Expect(creds.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), 5*time.Second))
+			})
+
+			It("reuses the client token", func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/v1/kubernetes/creds/arcade"),
+					ghttp.VerifyHeaderKV("x-vault-token", "hvs.fake-client-token"),
+					ghttp.RespondWith(http.StatusOK, payloadCredentials),
+				))
+
+				_, err = client.NewToken(context.Background())
+				Expect(err).To(BeNil())
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+
+		When("the client token does not expire", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, strings.Replace(payloadLogin, `"lease_duration": 2764800`, `"lease_duration": 0`, 1)),
+					ghttp.RespondWith(http.StatusOK, payloadCredentials),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/kubernetes/creds/arcade"),
+						ghttp.VerifyHeaderKV("x-vault-token", "hvs.fake-client-token"),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, "/v1/auth/kubernetes/login"),
+						ghttp.RespondWith(http.StatusOK, payloadLogin),
+					),
+					ghttp.RespondWith(http.StatusOK, payloadCredentials),
+				)
+			})
+
+			It("reuses the client token until it is revoked", func() {
+				Expect(err).To(BeNil())
+
+				_, err = client.NewToken(context.Background())
+				Expect(err).ToNot(BeNil())
+
+				_, err = client.NewToken(context.Background())
+				Expect(err).To(BeNil())
+				Expect(server.ReceivedRequests()).To(HaveLen(5))
+			})
+		})
+	})
+
+	Describe("#RevokeLease", func() {
+		JustBeforeEach(func() {
+			err = client.RevokeLease(context.Background(), "kubernetes/creds/arcade/cjzmN7sSlbWYwT1TFKb7GqkE")
+		})
+
+		When("revoking fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, payloadLogin),
+					ghttp.RespondWith(http.StatusForbidden, nil),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("error revoking lease: 403 Forbidden"))
+			})
+		})
+
+		When("it succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, payloadLogin),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, "/v1/sys/leases/revoke"),
+						ghttp.VerifyHeaderKV("x-vault-token", "hvs.fake-client-token"),
+						ghttp.VerifyJSON(`{"lease_id":"kubernetes/creds/arcade/cjzmN7sSlbWYwT1TFKb7GqkE"}`),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,27 @@
+package vault
+
+import "time"
+
+// Credentials is the response of the Kubernetes secrets engine creds endpoint.
+type Credentials struct {
+	RequestID     string `json:"request_id"`
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+	Data          struct {
+		ServiceAccountName      string `json:"service_account_name"`
+		ServiceAccountNamespace string `json:"service_account_namespace"`
+		ServiceAccountToken     string `json:"service_account_token"`
+	} `json:"data"`
+	// ExpiresAt is calculated from the lease duration when the
+	// credentials are created.
+	ExpiresAt time.Time `json:"-"`
+}
+
+// loginResponse is the response of a Vault auth method login endpoint.
+type loginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
@@ -0,0 +1,31 @@
+package vault_test
+
+const payloadLogin = `{
+  "request_id": "0b3d8f7a-5f3e-4c4e-8b1a-6c1f3d1e2a3b",
+  "lease_id": "",
+  "renewable": false,
+  "lease_duration": 0,
+  "data": null,
+  "auth": {
+    "client_token": "hvs.fake-client-token",
+    "accessor": "fake-accessor",
+    "policies": ["default", "arcade"],
+    "lease_duration": 2764800,
+    "renewable": true
+  }
+}`
+
+const payloadCredentials = `{
+  "request_id": "b2d1c9e4-6a7f-4d2b-9c3e-1f2a3b4c5d6e",
+  "lease_id": "kubernetes/creds/arcade/cjzmN7sSlbWYwT1TFKb7GqkE",
+  "renewable": false,
+  "lease_duration": 3600,
+  "data": {
+    "service_account_name": "v-token-arcade-1616668698-abcdef",
+    "service_account_namespace": "spinnaker",
+    "service_account_token": "eyJhbGciOiJSUzI1NiIsImtpZCI6ImZha2UifQ.eyJzdWIiOiJ2LXRva2VuLWFyY2FkZSJ9.ZmFrZQ"
+  },
+  "wrap_info": null,
+  "warnings": null,
+  "auth": null
+}`
@@ -0,0 +1,44 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/homedepot/arcade/pkg/provider"
+)
+
+// NewTokenProvider returns a provider.TokenProvider that generates
+// service account tokens with c. Replaced tokens have their lease revoked.
+func NewTokenProvider(c Client) provider.TokenProvider {
+	return &tokenProvider{c: c}
+}
+
+type tokenProvider struct {
+	c Client
+}
+
+func (tp *tokenProvider) NewToken(ctx context.Context) (provider.Token, error) {
+	creds, err := tp.c.NewToken(ctx)
+	if err != nil {
+		return provider.Token{}, err
+	}
+
+	return provider.Token{
+		Token:     creds.Data.ServiceAccountToken,
+		ExpiresAt: creds.ExpiresAt,
+		ID:        creds.LeaseID,
+		Metadata: map[string]string{
+			"serviceAccountName":      creds.Data.ServiceAccountName,
+			"serviceAccountNamespace": creds.Data.ServiceAccountNamespace,
+		},
+	}, nil
+}
+
+// Revoke revokes the lease of t so Vault cleans up the service account
+// token in the target cluster.
+func (tp *tokenProvider) Revoke(ctx context.Context, t provider.Token) error {
+	if t.ID == "" {
+		return nil
+	}
+
+	return tp.c.RevokeLease(ctx, t.ID)
+}
@@ -0,0 +1,662 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package vaultfakes
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/homedepot/arcade/pkg/vault"
+)
+
+type FakeClient struct {
+	NewTokenStub        func(context.Context) (vault.Credentials, error)
+	newTokenMutex       sync.RWMutex
+	newTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	newTokenReturns struct {
+		result1 vault.Credentials
+		result2 error
+	}
+	newTokenReturnsOnCall map[int]struct {
+		result1 vault.Credentials
+		result2 error
+	}
+	RevokeLeaseStub        func(context.Context, string) error
+	revokeLeaseMutex       sync.RWMutex
+	revokeLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	revokeLeaseReturns struct {
+		result1 error
+	}
+	revokeLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WithAuthMethodStub        func(string)
+	withAuthMethodMutex       sync.RWMutex
+	withAuthMethodArgsForCall []struct {
+		arg1 string
+	}
+	WithAuthMountPathStub        func(string)
+	withAuthMountPathMutex       sync.RWMutex
+	withAuthMountPathArgsForCall []struct {
+		arg1 string
+	}
+	WithAuthRoleStub        func(string)
+	withAuthRoleMutex       sync.RWMutex
+	withAuthRoleArgsForCall []struct {
+		arg1 string
+	}
+	WithJWTFileStub        func(string)
+	withJWTFileMutex       sync.RWMutex
+	withJWTFileArgsForCall []struct {
+		arg1 string
+	}
+	WithKubernetesNamespaceStub        func(string)
+	withKubernetesNamespaceMutex       sync.RWMutex
+	withKubernetesNamespaceArgsForCall []struct {
+		arg1 string
+	}
+	WithMountPathStub        func(string)
+	withMountPathMutex       sync.RWMutex
+	withMountPathArgsForCall []struct {
+		arg1 string
+	}
+	WithRoleStub        func(string)
+	withRoleMutex       sync.RWMutex
+	withRoleArgsForCall []struct {
+		arg1 string
+	}
+	WithRoleIDStub        func(string)
+	withRoleIDMutex       sync.RWMutex
+	withRoleIDArgsForCall []struct {
+		arg1 string
+	}
+	WithSecretIDStub        func(string)
+	withSecretIDMutex       sync.RWMutex
+	withSecretIDArgsForCall []struct {
+		arg1 string
+	}
+	WithTTLStub        func(string)
+	withTTLMutex       sync.RWMutex
+	withTTLArgsForCall []struct {
+		arg1 string
+	}
+	WithTransportStub        func(*http.Transport)
+	withTransportMutex       sync.RWMutex
+	withTransportArgsForCall []struct {
+		arg1 *http.Transport
+	}
+	WithURLStub        func(string)
+	withURLMutex       sync.RWMutex
+	withURLArgsForCall []struct {
+		arg1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) NewToken(arg1 context.Context) (vault.Credentials, error) {
+	fake.newTokenMutex.Lock()
+	ret, specificReturn := fake.newTokenReturnsOnCall[len(fake.newTokenArgsForCall)]
+	fake.newTokenArgsForCall = append(fake.newTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.NewTokenStub
+	fakeReturns := fake.newTokenReturns
+	fake.recordInvocation("NewToken", []interface{}{arg1})
+	fake.newTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) NewTokenCallCount() int {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	return len(fake.newTokenArgsForCall)
+}
+
+func (fake *FakeClient) NewTokenCalls(stub func(context.Context) (vault.Credentials, error)) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = stub
+}
+
+func (fake *FakeClient) NewTokenArgsForCall(i int) context.Context {
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	argsForCall := fake.newTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) NewTokenReturns(result1 vault.Credentials, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	fake.newTokenReturns = struct {
+		result1 vault.Credentials
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) NewTokenReturnsOnCall(i int, result1 vault.Credentials, result2 error) {
+	fake.newTokenMutex.Lock()
+	defer fake.newTokenMutex.Unlock()
+	fake.NewTokenStub = nil
+	if fake.newTokenReturnsOnCall == nil {
+		fake.newTokenReturnsOnCall = make(map[int]struct {
+			result1 vault.Credentials
+			result2 error
+		})
+	}
+	fake.newTokenReturnsOnCall[i] = struct {
+		result1 vault.Credentials
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeLease(arg1 context.Context, arg2 string) error {
+	fake.revokeLeaseMutex.Lock()
+	ret, specificReturn := fake.revokeLeaseReturnsOnCall[len(fake.revokeLeaseArgsForCall)]
+	fake.revokeLeaseArgsForCall = append(fake.revokeLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeLeaseStub
+	fakeReturns := fake.revokeLeaseReturns
+	fake.recordInvocation("RevokeLease", []interface{}{arg1, arg2})
+	fake.revokeLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) RevokeLeaseCallCount() int {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	return len(fake.revokeLeaseArgsForCall)
+}
+
+func (fake *FakeClient) RevokeLeaseCalls(stub func(context.Context, string) error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = stub
+}
+
+func (fake *FakeClient) RevokeLeaseArgsForCall(i int) (context.Context, string) {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	argsForCall := fake.revokeLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) RevokeLeaseReturns(result1 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	fake.revokeLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RevokeLeaseReturnsOnCall(i int, result1 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	if fake.revokeLeaseReturnsOnCall == nil {
+		fake.revokeLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) WithAuthMethod(arg1 string) {
+	fake.withAuthMethodMutex.Lock()
+	fake.withAuthMethodArgsForCall = append(fake.withAuthMethodArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAuthMethodStub
+	fake.recordInvocation("WithAuthMethod", []interface{}{arg1})
+	fake.withAuthMethodMutex.Unlock()
+	if stub != nil {
+		fake.WithAuthMethodStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAuthMethodCallCount() int {
+	fake.withAuthMethodMutex.RLock()
+	defer fake.withAuthMethodMutex.RUnlock()
+	return len(fake.withAuthMethodArgsForCall)
+}
+
+func (fake *FakeClient) WithAuthMethodCalls(stub func(string)) {
+	fake.withAuthMethodMutex.Lock()
+	defer fake.withAuthMethodMutex.Unlock()
+	fake.WithAuthMethodStub = stub
+}
+
+func (fake *FakeClient) WithAuthMethodArgsForCall(i int) string {
+	fake.withAuthMethodMutex.RLock()
+	defer fake.withAuthMethodMutex.RUnlock()
+	argsForCall := fake.withAuthMethodArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithAuthMountPath(arg1 string) {
+	fake.withAuthMountPathMutex.Lock()
+	fake.withAuthMountPathArgsForCall = append(fake.withAuthMountPathArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAuthMountPathStub
+	fake.recordInvocation("WithAuthMountPath", []interface{}{arg1})
+	fake.withAuthMountPathMutex.Unlock()
+	if stub != nil {
+		fake.WithAuthMountPathStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAuthMountPathCallCount() int {
+	fake.withAuthMountPathMutex.RLock()
+	defer fake.withAuthMountPathMutex.RUnlock()
+	return len(fake.withAuthMountPathArgsForCall)
+}
+
+func (fake *FakeClient) WithAuthMountPathCalls(stub func(string)) {
+	fake.withAuthMountPathMutex.Lock()
+	defer fake.withAuthMountPathMutex.Unlock()
+	fake.WithAuthMountPathStub = stub
+}
+
+func (fake *FakeClient) WithAuthMountPathArgsForCall(i int) string {
+	fake.withAuthMountPathMutex.RLock()
+	defer fake.withAuthMountPathMutex.RUnlock()
+	argsForCall := fake.withAuthMountPathArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithAuthRole(arg1 string) {
+	fake.withAuthRoleMutex.Lock()
+	fake.withAuthRoleArgsForCall = append(fake.withAuthRoleArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAuthRoleStub
+	fake.recordInvocation("WithAuthRole", []interface{}{arg1})
+	fake.withAuthRoleMutex.Unlock()
+	if stub != nil {
+		fake.WithAuthRoleStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithAuthRoleCallCount() int {
+	fake.withAuthRoleMutex.RLock()
+	defer fake.withAuthRoleMutex.RUnlock()
+	return len(fake.withAuthRoleArgsForCall)
+}
+
+func (fake *FakeClient) WithAuthRoleCalls(stub func(string)) {
+	fake.withAuthRoleMutex.Lock()
+	defer fake.withAuthRoleMutex.Unlock()
+	fake.WithAuthRoleStub = stub
+}
+
+func (fake *FakeClient) WithAuthRoleArgsForCall(i int) string {
+	fake.withAuthRoleMutex.RLock()
+	defer fake.withAuthRoleMutex.RUnlock()
+	argsForCall := fake.withAuthRoleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithJWTFile(arg1 string) {
+	fake.withJWTFileMutex.Lock()
+	fake.withJWTFileArgsForCall = append(fake.withJWTFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithJWTFileStub
+	fake.recordInvocation("WithJWTFile", []interface{}{arg1})
+	fake.withJWTFileMutex.Unlock()
+	if stub != nil {
+		fake.WithJWTFileStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithJWTFileCallCount() int {
+	fake.withJWTFileMutex.RLock()
+	defer fake.withJWTFileMutex.RUnlock()
+	return len(fake.withJWTFileArgsForCall)
+}
+
+func (fake *FakeClient) WithJWTFileCalls(stub func(string)) {
+	fake.withJWTFileMutex.Lock()
+	defer fake.withJWTFileMutex.Unlock()
+	fake.WithJWTFileStub = stub
+}
+
+func (fake *FakeClient) WithJWTFileArgsForCall(i int) string {
+	fake.withJWTFileMutex.RLock()
+	defer fake.withJWTFileMutex.RUnlock()
+	argsForCall := fake.withJWTFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithKubernetesNamespace(arg1 string) {
+	fake.withKubernetesNamespaceMutex.Lock()
+	fake.withKubernetesNamespaceArgsForCall = append(fake.withKubernetesNamespaceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithKubernetesNamespaceStub
+	fake.recordInvocation("WithKubernetesNamespace", []interface{}{arg1})
+	fake.withKubernetesNamespaceMutex.Unlock()
+	if stub != nil {
+		fake.WithKubernetesNamespaceStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithKubernetesNamespaceCallCount() int {
+	fake.withKubernetesNamespaceMutex.RLock()
+	defer fake.withKubernetesNamespaceMutex.RUnlock()
+	return len(fake.withKubernetesNamespaceArgsForCall)
+}
+
+func (fake *FakeClient) WithKubernetesNamespaceCalls(stub func(string)) {
+	fake.withKubernetesNamespaceMutex.Lock()
+	defer fake.withKubernetesNamespaceMutex.Unlock()
+	fake.WithKubernetesNamespaceStub = stub
+}
+
+func (fake *FakeClient) WithKubernetesNamespaceArgsForCall(i int) string {
+	fake.withKubernetesNamespaceMutex.RLock()
+	defer fake.withKubernetesNamespaceMutex.RUnlock()
+	argsForCall := fake.withKubernetesNamespaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithMountPath(arg1 string) {
+	fake.withMountPathMutex.Lock()
+	fake.withMountPathArgsForCall = append(fake.withMountPathArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithMountPathStub
+	fake.recordInvocation("WithMountPath", []interface{}{arg1})
+	fake.withMountPathMutex.Unlock()
+	if stub != nil {
+		fake.WithMountPathStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithMountPathCallCount() int {
+	fake.withMountPathMutex.RLock()
+	defer fake.withMountPathMutex.RUnlock()
+	return len(fake.withMountPathArgsForCall)
+}
+
+func (fake *FakeClient) WithMountPathCalls(stub func(string)) {
+	fake.withMountPathMutex.Lock()
+	defer fake.withMountPathMutex.Unlock()
+	fake.WithMountPathStub = stub
+}
+
+func (fake *FakeClient) WithMountPathArgsForCall(i int) string {
+	fake.withMountPathMutex.RLock()
+	defer fake.withMountPathMutex.RUnlock()
+	argsForCall := fake.withMountPathArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithRole(arg1 string) {
+	fake.withRoleMutex.Lock()
+	fake.withRoleArgsForCall = append(fake.withRoleArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithRoleStub
+	fake.recordInvocation("WithRole", []interface{}{arg1})
+	fake.withRoleMutex.Unlock()
+	if stub != nil {
+		fake.WithRoleStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithRoleCallCount() int {
+	fake.withRoleMutex.RLock()
+	defer fake.withRoleMutex.RUnlock()
+	return len(fake.withRoleArgsForCall)
+}
+
+func (fake *FakeClient) WithRoleCalls(stub func(string)) {
+	fake.withRoleMutex.Lock()
+	defer fake.withRoleMutex.Unlock()
+	fake.WithRoleStub = stub
+}
+
+func (fake *FakeClient) WithRoleArgsForCall(i int) string {
+	fake.withRoleMutex.RLock()
+	defer fake.withRoleMutex.RUnlock()
+	argsForCall := fake.withRoleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithRoleID(arg1 string) {
+	fake.withRoleIDMutex.Lock()
+	fake.withRoleIDArgsForCall = append(fake.withRoleIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithRoleIDStub
+	fake.recordInvocation("WithRoleID", []interface{}{arg1})
+	fake.withRoleIDMutex.Unlock()
+	if stub != nil {
+		fake.WithRoleIDStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithRoleIDCallCount() int {
+	fake.withRoleIDMutex.RLock()
+	defer fake.withRoleIDMutex.RUnlock()
+	return len(fake.withRoleIDArgsForCall)
+}
+
+func (fake *FakeClient) WithRoleIDCalls(stub func(string)) {
+	fake.withRoleIDMutex.Lock()
+	defer fake.withRoleIDMutex.Unlock()
+	fake.WithRoleIDStub = stub
+}
+
+func (fake *FakeClient) WithRoleIDArgsForCall(i int) string {
+	fake.withRoleIDMutex.RLock()
+	defer fake.withRoleIDMutex.RUnlock()
+	argsForCall := fake.withRoleIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithSecretID(arg1 string) {
+	fake.withSecretIDMutex.Lock()
+	fake.withSecretIDArgsForCall = append(fake.withSecretIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithSecretIDStub
+	fake.recordInvocation("WithSecretID", []interface{}{arg1})
+	fake.withSecretIDMutex.Unlock()
+	if stub != nil {
+		fake.WithSecretIDStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithSecretIDCallCount() int {
+	fake.withSecretIDMutex.RLock()
+	defer fake.withSecretIDMutex.RUnlock()
+	return len(fake.withSecretIDArgsForCall)
+}
+
+func (fake *FakeClient) WithSecretIDCalls(stub func(string)) {
+	fake.withSecretIDMutex.Lock()
+	defer fake.withSecretIDMutex.Unlock()
+	fake.WithSecretIDStub = stub
+}
+
+func (fake *FakeClient) WithSecretIDArgsForCall(i int) string {
+	fake.withSecretIDMutex.RLock()
+	defer fake.withSecretIDMutex.RUnlock()
+	argsForCall := fake.withSecretIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithTTL(arg1 string) {
+	fake.withTTLMutex.Lock()
+	fake.withTTLArgsForCall = append(fake.withTTLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithTTLStub
+	fake.recordInvocation("WithTTL", []interface{}{arg1})
+	fake.withTTLMutex.Unlock()
+	if stub != nil {
+		fake.WithTTLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTTLCallCount() int {
+	fake.withTTLMutex.RLock()
+	defer fake.withTTLMutex.RUnlock()
+	return len(fake.withTTLArgsForCall)
+}
+
+func (fake *FakeClient) WithTTLCalls(stub func(string)) {
+	fake.withTTLMutex.Lock()
+	defer fake.withTTLMutex.Unlock()
+	fake.WithTTLStub = stub
+}
+
+func (fake *FakeClient) WithTTLArgsForCall(i int) string {
+	fake.withTTLMutex.RLock()
+	defer fake.withTTLMutex.RUnlock()
+	argsForCall := fake.withTTLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithTransport(arg1 *http.Transport) {
+	fake.withTransportMutex.Lock()
+	fake.withTransportArgsForCall = append(fake.withTransportArgsForCall, struct {
+		arg1 *http.Transport
+	}{arg1})
+	stub := fake.WithTransportStub
+	fake.recordInvocation("WithTransport", []interface{}{arg1})
+	fake.withTransportMutex.Unlock()
+	if stub != nil {
+		fake.WithTransportStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithTransportCallCount() int {
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	return len(fake.withTransportArgsForCall)
+}
+
+func (fake *FakeClient) WithTransportCalls(stub func(*http.Transport)) {
+	fake.withTransportMutex.Lock()
+	defer fake.withTransportMutex.Unlock()
+	fake.WithTransportStub = stub
+}
+
+func (fake *FakeClient) WithTransportArgsForCall(i int) *http.Transport {
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	argsForCall := fake.withTransportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) WithURL(arg1 string) {
+	fake.withURLMutex.Lock()
+	fake.withURLArgsForCall = append(fake.withURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithURLStub
+	fake.recordInvocation("WithURL", []interface{}{arg1})
+	fake.withURLMutex.Unlock()
+	if stub != nil {
+		fake.WithURLStub(arg1)
+	}
+}
+
+func (fake *FakeClient) WithURLCallCount() int {
+	fake.withURLMutex.RLock()
+	defer fake.withURLMutex.RUnlock()
+	return len(fake.withURLArgsForCall)
+}
+
+func (fake *FakeClient) WithURLCalls(stub func(string)) {
+	fake.withURLMutex.Lock()
+	defer fake.withURLMutex.Unlock()
+	fake.WithURLStub = stub
+}
+
+func (fake *FakeClient) WithURLArgsForCall(i int) string {
+	fake.withURLMutex.RLock()
+	defer fake.withURLMutex.RUnlock()
+	argsForCall := fake.withURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newTokenMutex.RLock()
+	defer fake.newTokenMutex.RUnlock()
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	fake.withAuthMethodMutex.RLock()
+	defer fake.withAuthMethodMutex.RUnlock()
+	fake.withAuthMountPathMutex.RLock()
+	defer fake.withAuthMountPathMutex.RUnlock()
+	fake.withAuthRoleMutex.RLock()
+	defer fake.withAuthRoleMutex.RUnlock()
+	fake.withJWTFileMutex.RLock()
+	defer fake.withJWTFileMutex.RUnlock()
+	fake.withKubernetesNamespaceMutex.RLock()
+	defer fake.withKubernetesNamespaceMutex.RUnlock()
+	fake.withMountPathMutex.RLock()
+	defer fake.withMountPathMutex.RUnlock()
+	fake.withRoleMutex.RLock()
+	defer fake.withRoleMutex.RUnlock()
+	fake.withRoleIDMutex.RLock()
+	defer fake.withRoleIDMutex.RUnlock()
+	fake.withSecretIDMutex.RLock()
+	defer fake.withSecretIDMutex.RUnlock()
+	fake.withTTLMutex.RLock()
+	defer fake.withTTLMutex.RUnlock()
+	fake.withTransportMutex.RLock()
+	defer fake.withTransportMutex.RUnlock()
+	fake.withURLMutex.RLock()
+	defer fake.withURLMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ vault.Client = new(FakeClient)